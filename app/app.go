@@ -12,6 +12,24 @@ type Module interface {
 	Run(App) error
 }
 
+// NamedModule is a module with an unique name. Other modules can depend on it by the name.
+type NamedModule interface {
+	Module
+	ModuleName() string
+}
+
+// DependentModule is a module which must be registered and inited after modules it depends on
+type DependentModule interface {
+	Module
+	ModuleDependencies() []string
+}
+
+// StoppableModule is a module with stop hook. Stop hooks are run in reverse order on shutdown.
+type StoppableModule interface {
+	Module
+	Stop(App) error
+}
+
 // Bootstrap represent bootstrap of a app
 type Bootstrap interface {
 	Register(Module) error
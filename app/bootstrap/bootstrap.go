@@ -1,16 +1,37 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
+const (
+	// DefaultGracePeriod is default time to wait for application tasks after a shutdown signal
+	DefaultGracePeriod = time.Second * 30
+)
+
+// Params describe bootstrap options
+type Params struct {
+	// GracePeriod is maximum time to wait for application tasks after a shutdown signal
+	GracePeriod time.Duration
+	// FailFast kill the application scope on the first module run error.
+	// Otherwise all modules run to completion.
+	FailFast bool
+	// Signals is a source of shutdown signals (SIGINT / SIGTERM are handled by default)
+	Signals <-chan os.Signal
+}
+
 // Bootstrap is default boot sequence
 type Bootstrap struct {
 	gapp    app.App
+	params  Params
 	modules []app.Module
 	inited  bool
 	runed   bool
@@ -18,8 +39,17 @@ type Bootstrap struct {
 
 // NewBootstrap create new Bootstrap object
 func NewBootstrap(gapp app.App) *Bootstrap {
+	return NewBootstrapWithParams(gapp, Params{})
+}
+
+// NewBootstrapWithParams create new Bootstrap object with custom options
+func NewBootstrapWithParams(gapp app.App, params Params) *Bootstrap {
+	if params.GracePeriod == 0 {
+		params.GracePeriod = DefaultGracePeriod
+	}
 	return &Bootstrap{
 		gapp:    gapp,
+		params:  params,
 		modules: []app.Module{},
 		inited:  false,
 		runed:   false,
@@ -35,17 +65,23 @@ func (b *Bootstrap) Register(m app.Module) error {
 	return nil
 }
 
-// Init all modules
-func (b *Bootstrap) Init() error {
+// Init all modules (in order of modules dependencies)
+func (b *Bootstrap) Init() (err error) {
 	if b.inited {
 		return goaterr.Errorf("Bootstrap can not be inited twice")
 	}
 	b.inited = true
+	if b.modules, err = sortModules(b.modules); err != nil {
+		return err
+	}
 	for _, module := range b.modules {
 		if err := module.RegisterDependencies(b.gapp); err != nil {
 			return err
 		}
 	}
+	if err := ValidateConfig(b.gapp); err != nil {
+		return err
+	}
 	for _, module := range b.modules {
 		if err := module.InitDependencies(b.gapp); err != nil {
 			return err
@@ -58,6 +94,8 @@ func (b *Bootstrap) Init() error {
 func (b *Bootstrap) Run() (err error) {
 	var (
 		appScope = b.gapp.AppScope()
+		results  = newRunResults()
+		signals  = b.params.Signals
 		errs     []error
 	)
 	if !b.inited {
@@ -67,21 +105,90 @@ func (b *Bootstrap) Run() (err error) {
 		return goaterr.Errorf("Bootstrap.Run can not be run twice")
 	}
 	b.runed = true
-	appScope.AddTasks(len(b.modules))
+	if signals == nil {
+		// signals are handled before modules start so an early signal doesn't kill the process
+		osSignals := make(chan os.Signal, 2)
+		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(osSignals)
+		signals = osSignals
+	}
+	if err = appScope.AddTasks(len(b.modules)); err != nil {
+		return err
+	}
 	for _, module := range b.modules {
 		go func(module app.Module) {
 			defer appScope.DoneTask()
-			if err = module.Run(b.gapp); err != nil {
-				errs = append(errs, err)
+			if err := module.Run(b.gapp); err != nil {
+				results.add(goaterr.Wrapf("bootstrap: %s module run error", err, moduleName(module)))
+				if b.params.FailFast {
+					appScope.Kill()
+				}
 			}
 		}(module)
 	}
-	if err = appScope.Wait(); err != nil {
-		errs = append(errs, err)
+	waitErrs, forced := b.wait(signals)
+	errs = goaterr.AppendError(results.errors(), waitErrs...)
+	if forced {
+		// the second signal forces exit (stop hooks and application close are skipped)
+		return goaterr.ToError(errs)
 	}
+	errs = goaterr.AppendError(errs, b.stop()...)
 	return goaterr.ToError(goaterr.AppendError(errs, app.CloseApp(b.gapp)))
 }
 
+// wait for application tasks. A SIGINT / SIGTERM signal starts graceful shutdown:
+// BeforeCloseEvent is triggered, the application scope is killed and its tasks have
+// a grace period to finish. CloseEvent is triggered after the tasks finish or the grace period
+// timeout (it returns an error). A second signal forces exit at once (CloseEvent is skipped).
+func (b *Bootstrap) wait(signals <-chan os.Signal) (errs []error, forced bool) {
+	var (
+		appScope = b.gapp.AppScope()
+		done     = make(chan error, 1)
+		timer    *time.Timer
+		sig      os.Signal
+	)
+	go func() {
+		done <- appScope.Wait()
+	}()
+	select {
+	case err := <-done:
+		return goaterr.AppendError(errs, err), false
+	case sig = <-signals:
+	}
+	errs = goaterr.AppendError(errs, appScope.Trigger(app.BeforeCloseEvent, nil))
+	appScope.Kill()
+	timer = time.NewTimer(b.params.GracePeriod)
+	defer timer.Stop()
+	select {
+	case <-done:
+		// the scope is killed by the signal so context cancellation is not an error
+		for _, err := range appScope.Errors() {
+			if err != context.Canceled {
+				errs = append(errs, err)
+			}
+		}
+	case <-timer.C:
+		errs = append(errs, goaterr.Errorf("bootstrap: application tasks are not finished in %v after %v signal", b.params.GracePeriod, sig))
+	case sig = <-signals:
+		return append(errs, goaterr.Errorf("bootstrap: forced exit by second %v signal", sig)), true
+	}
+	return goaterr.AppendError(errs, appScope.Trigger(app.CloseEvent, nil)), false
+}
+
+// stop run modules stop hooks in reverse order
+func (b *Bootstrap) stop() (errs []error) {
+	for i := len(b.modules) - 1; i >= 0; i-- {
+		module, ok := b.modules[i].(app.StoppableModule)
+		if !ok {
+			continue
+		}
+		if err := module.Stop(b.gapp); err != nil {
+			errs = append(errs, goaterr.Wrapf("bootstrap: %s module stop error", err, moduleName(module)))
+		}
+	}
+	return errs
+}
+
 // ShowError print error / errors to stderr
 func (b *Bootstrap) ShowError(inerr error) (code int, err error) {
 	var (
@@ -0,0 +1,110 @@
+package bootstrap
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+	"github.com/goatcms/goatcore/varutil/totype"
+)
+
+// ValidateConfig check config scope values by registered config definitions.
+// Undefined optional values are set to defaults and all defined values are
+// converted to declared types. It returns all problems as a single error.
+func ValidateConfig(a app.App) (err error) {
+	return ValidateConfigData(a.CommandScope(), a.ConfigScope())
+}
+
+// ValidateConfigData check config data by config definitions from schema data
+// (command scope keeps the definitions). See ValidateConfig.
+func ValidateConfigData(schema, config app.DataScope) (err error) {
+	var (
+		keys  []string
+		ins   interface{}
+		value interface{}
+		errs  []error
+	)
+	if keys, err = schema.Keys(); err != nil {
+		return err
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, app.ConfigSchemaPrefix) {
+			continue
+		}
+		if ins, err = schema.Get(key); err != nil {
+			return err
+		}
+		def, ok := ins.(app.ConfigDefinition)
+		if !ok {
+			errs = append(errs, goaterr.Errorf("config: %s must be an app.ConfigDefinition (take %T)", key, ins))
+			continue
+		}
+		if value, err = config.Get(def.Key); err != nil {
+			return err
+		}
+		if value == nil {
+			if def.Required {
+				errs = append(errs, goaterr.Errorf("config: %s is required (%s)", def.Key, def.Description))
+				continue
+			}
+			if def.Default == nil {
+				continue
+			}
+			value = def.Default
+		}
+		if value, err = convertConfigValue(def.Type, value); err != nil {
+			errs = append(errs, goaterr.Wrapf("config: %s must be a %s value", err, def.Key, def.Type))
+			continue
+		}
+		if err = config.Set(def.Key, value); err != nil {
+			return err
+		}
+	}
+	return goaterr.ToError(errs)
+}
+
+func convertConfigValue(t app.ConfigType, value interface{}) (result interface{}, err error) {
+	switch t {
+	case app.ConfigString:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case app.ConfigInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		case string:
+			return totype.StringToInt(v)
+		}
+	case app.ConfigFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return totype.StringToFloat64(v)
+		}
+	case app.ConfigBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return totype.StringToBool(v)
+		}
+	default:
+		return nil, goaterr.Errorf("unknown config type %s", t)
+	}
+	return nil, goaterr.Errorf("incorrect value %v (%T)", value, value)
+}
@@ -0,0 +1,143 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/scope"
+)
+
+type configTestModule struct {
+	defs   []app.ConfigDefinition
+	inited bool
+}
+
+func (m *configTestModule) RegisterDependencies(a app.App) (err error) {
+	for _, def := range m.defs {
+		if err = app.RegisterConfig(a, def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *configTestModule) InitDependencies(a app.App) error {
+	m.inited = true
+	return nil
+}
+
+func (m *configTestModule) Run(a app.App) error {
+	return nil
+}
+
+func TestValidateConfigConvertAndDefaults(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		value  interface{}
+		module = &configTestModule{
+			defs: []app.ConfigDefinition{
+				{Key: "db.host", Type: app.ConfigString, Required: true},
+				{Key: "db.port", Type: app.ConfigInt, Required: true},
+				{Key: "db.timeout", Type: app.ConfigFloat, Default: 1.5},
+				{Key: "db.ssl", Type: app.ConfigBool, Default: true},
+				{Key: "db.user", Type: app.ConfigString},
+			},
+		}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	mapp.ConfigScope().Set("db.host", "localhost")
+	mapp.ConfigScope().Set("db.port", float64(5432))
+	mapp.ConfigScope().Set("db.ssl", "false")
+	bootstrap := NewBootstrap(mapp)
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if !module.inited {
+		t.Errorf("module should be inited")
+	}
+	expected := map[string]interface{}{
+		"db.host":    "localhost",
+		"db.port":    5432,
+		"db.timeout": 1.5,
+		"db.ssl":     false,
+		"db.user":    nil,
+	}
+	for key, expectedValue := range expected {
+		if value, err = mapp.ConfigScope().Get(key); err != nil {
+			t.Error(err)
+			return
+		}
+		if value != expectedValue {
+			t.Errorf("expected %s equals to %v (%T) and take %v (%T)", key, expectedValue, expectedValue, value, value)
+		}
+	}
+}
+
+func TestValidateConfigReportAllErrors(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		module = &configTestModule{
+			defs: []app.ConfigDefinition{
+				{Key: "db.host", Type: app.ConfigString, Required: true, Description: "database host"},
+				{Key: "db.port", Type: app.ConfigInt, Required: true},
+				{Key: "db.ssl", Type: app.ConfigBool},
+			},
+		}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	mapp.ConfigScope().Set("db.port", "not-a-number")
+	mapp.ConfigScope().Set("db.ssl", float64(1))
+	bootstrap := NewBootstrap(mapp)
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err == nil {
+		t.Errorf("Init should return an error for incorrect config")
+		return
+	}
+	if module.inited {
+		t.Errorf("module dependencies should not be inited for incorrect config")
+	}
+	msg := err.Error()
+	for _, key := range []string{"db.host", "db.port", "db.ssl"} {
+		if !strings.Contains(msg, key) {
+			t.Errorf("error should report %s problem and take: %s", key, msg)
+		}
+	}
+}
+
+func TestValidateConfigIncorrectDefinition(t *testing.T) {
+	t.Parallel()
+	var (
+		schema = scope.NewDataScope(map[string]interface{}{
+			app.ConfigSchemaPrefix + "db.host": "not-a-definition",
+		})
+		config = scope.NewDataScope(map[string]interface{}{})
+		err    error
+	)
+	if err = ValidateConfigData(schema, config); err == nil {
+		t.Errorf("expected error for incorrect config definition")
+		return
+	}
+	if !strings.Contains(err.Error(), app.ConfigSchemaPrefix+"db.host") {
+		t.Errorf("error should contain the definition key and take: %s", err.Error())
+	}
+}
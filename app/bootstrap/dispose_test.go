@@ -0,0 +1,81 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+type disposeTestResource struct {
+	name     string
+	disposed *[]string
+	fail     bool
+}
+
+func (r *disposeTestResource) Close() error {
+	*r.disposed = append(*r.disposed, r.name)
+	if r.fail {
+		return goaterr.Errorf("%s close error", r.name)
+	}
+	return nil
+}
+
+type disposeTestModule struct {
+	disposed []string
+}
+
+func (m *disposeTestModule) RegisterDependencies(a app.App) error {
+	dp := a.DependencyProvider()
+	return goaterr.ToError(goaterr.AppendError(nil,
+		dp.AddDefaultFactory("Pool", func(dp dependency.Provider) (interface{}, error) {
+			return &disposeTestResource{name: "Pool", disposed: &m.disposed, fail: true}, nil
+		}),
+		dp.AddDefaultFactory("Repository", func(dp dependency.Provider) (interface{}, error) {
+			if _, err := dp.Get("Pool"); err != nil {
+				return nil, err
+			}
+			return &disposeTestResource{name: "Repository", disposed: &m.disposed}, nil
+		}),
+	))
+}
+
+func (m *disposeTestModule) InitDependencies(a app.App) error {
+	_, err := a.DependencyProvider().Get("Repository")
+	return err
+}
+
+func (m *disposeTestModule) Run(a app.App) error {
+	return nil
+}
+
+func TestBootstrapRunDisposeDependencies(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		module = &disposeTestModule{}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrap(mapp)
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err == nil || !strings.Contains(err.Error(), "Pool close error") {
+		t.Errorf("expected dispose error and take %v", err)
+	}
+	if len(module.disposed) != 2 || module.disposed[0] != "Repository" || module.disposed[1] != "Pool" {
+		t.Errorf("expected dependencies disposed in reverse creation order and take %v", module.disposed)
+	}
+}
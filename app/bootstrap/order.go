@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// sortModules order modules topologically by declared dependencies (app.DependentModule).
+// Modules without dependencies between them keep registration order.
+func sortModules(modules []app.Module) (sorted []app.Module, err error) {
+	var (
+		names    = map[string]int{}
+		deps     = make([][]int, len(modules))
+		visited  = make([]bool, len(modules))
+		visiting = make([]bool, len(modules))
+		visit    func(i int, path []string) error
+	)
+	for i, module := range modules {
+		named, ok := module.(app.NamedModule)
+		if !ok {
+			continue
+		}
+		name := named.ModuleName()
+		if _, ok = names[name]; ok {
+			return nil, goaterr.Errorf("bootstrap: module %s is registered twice", name)
+		}
+		names[name] = i
+	}
+	for i, module := range modules {
+		dependent, ok := module.(app.DependentModule)
+		if !ok {
+			continue
+		}
+		for _, name := range dependent.ModuleDependencies() {
+			j, ok := names[name]
+			if !ok {
+				return nil, goaterr.Errorf("bootstrap: %s module depends on unregistered %s module", moduleName(module), name)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+	visit = func(i int, path []string) error {
+		if visited[i] {
+			return nil
+		}
+		path = append(path, moduleName(modules[i]))
+		if visiting[i] {
+			return goaterr.Errorf("bootstrap: modules dependency cycle %s", strings.Join(path, " -> "))
+		}
+		visiting[i] = true
+		for _, j := range deps[i] {
+			if err := visit(j, path); err != nil {
+				return err
+			}
+		}
+		visiting[i] = false
+		visited[i] = true
+		sorted = append(sorted, modules[i])
+		return nil
+	}
+	for i := range modules {
+		if err = visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func moduleName(module app.Module) string {
+	if named, ok := module.(app.NamedModule); ok {
+		return named.ModuleName()
+	}
+	return fmt.Sprintf("%T", module)
+}
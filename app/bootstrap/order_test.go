@@ -0,0 +1,141 @@
+package bootstrap
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+)
+
+type orderTestModule struct {
+	name string
+	deps []string
+	log  *orderTestLog
+}
+
+type orderTestLog struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *orderTestLog) add(entry string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+func (l *orderTestLog) String() string {
+	return strings.Join(l.entries, ",")
+}
+
+func (m *orderTestModule) ModuleName() string {
+	return m.name
+}
+
+func (m *orderTestModule) ModuleDependencies() []string {
+	return m.deps
+}
+
+func (m *orderTestModule) RegisterDependencies(a app.App) error {
+	m.log.add("register:" + m.name)
+	return nil
+}
+
+func (m *orderTestModule) InitDependencies(a app.App) error {
+	m.log.add("init:" + m.name)
+	return nil
+}
+
+func (m *orderTestModule) Run(a app.App) error {
+	return nil
+}
+
+func (m *orderTestModule) Stop(a app.App) error {
+	m.log.add("stop:" + m.name)
+	return nil
+}
+
+func TestBootstrapModulesOrder(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		err  error
+		log  = &orderTestLog{}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrap(mapp)
+	for _, module := range []app.Module{
+		&orderTestModule{name: "c", deps: []string{"b"}, log: log},
+		&orderTestModule{name: "b", deps: []string{"a"}, log: log},
+		&orderTestModule{name: "a", log: log},
+		&orderTestModule{name: "d", log: log},
+	} {
+		if err = bootstrap.Register(module); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "register:a,register:b,register:c,register:d," +
+		"init:a,init:b,init:c,init:d," +
+		"stop:d,stop:c,stop:b,stop:a"
+	if log.String() != expected {
+		t.Errorf("expected %s and take %s", expected, log.String())
+	}
+}
+
+func TestBootstrapModulesCycle(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		err  error
+		log  = &orderTestLog{}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrap(mapp)
+	bootstrap.Register(&orderTestModule{name: "a", deps: []string{"c"}, log: log})
+	bootstrap.Register(&orderTestModule{name: "b", deps: []string{"a"}, log: log})
+	bootstrap.Register(&orderTestModule{name: "c", deps: []string{"b"}, log: log})
+	if err = bootstrap.Init(); err == nil {
+		t.Errorf("expected cycle error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a -> c -> b -> a") {
+		t.Errorf("error should describe the cycle and take: %v", err)
+	}
+	if len(log.entries) != 0 {
+		t.Errorf("modules should not be registered when dependencies are incorrect (%s)", log.String())
+	}
+}
+
+func TestBootstrapModulesUnknownDependency(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		err  error
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrap(mapp)
+	bootstrap.Register(&orderTestModule{name: "a", deps: []string{"unknown"}, log: &orderTestLog{}})
+	if err = bootstrap.Init(); err == nil {
+		t.Errorf("expected unknown dependency error")
+	}
+}
@@ -0,0 +1,25 @@
+package bootstrap
+
+import "sync"
+
+// runResults collect modules run errors (it is safe for concurrent use)
+type runResults struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func newRunResults() *runResults {
+	return &runResults{}
+}
+
+func (results *runResults) add(err error) {
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	results.errs = append(results.errs, err)
+}
+
+func (results *runResults) errors() []error {
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	return append([]error{}, results.errs...)
+}
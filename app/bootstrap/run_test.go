@@ -0,0 +1,100 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+type runTestModule struct {
+	name    string
+	fail    bool
+	wait    bool
+	stopped bool
+}
+
+func (m *runTestModule) ModuleName() string {
+	return m.name
+}
+
+func (m *runTestModule) RegisterDependencies(a app.App) error {
+	return nil
+}
+
+func (m *runTestModule) InitDependencies(a app.App) error {
+	return nil
+}
+
+func (m *runTestModule) Run(a app.App) error {
+	if m.fail {
+		return goaterr.Errorf("%s failed", m.name)
+	}
+	if m.wait {
+		<-a.AppScope().Context().Done()
+		m.stopped = true
+	}
+	return nil
+}
+
+func TestBootstrapRunCollectErrors(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		err  error
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrap(mapp)
+	for i := 0; i < 20; i++ {
+		bootstrap.Register(&runTestModule{name: "module" + string(rune('a'+i)), fail: i%2 == 0})
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err == nil {
+		t.Errorf("expected modules errors")
+		return
+	}
+	msg := err.Error()
+	for i := 0; i < 20; i += 2 {
+		name := "module" + string(rune('a'+i))
+		if !strings.Contains(msg, "bootstrap: "+name+" module run error") {
+			t.Errorf("error should identify %s module and take: %s", name, msg)
+		}
+	}
+}
+
+func TestBootstrapRunFailFast(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp    *mockupapp.App
+		err     error
+		waiting = &runTestModule{name: "waiting", wait: true}
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrapWithParams(mapp, Params{
+		FailFast: true,
+	})
+	bootstrap.Register(waiting)
+	bootstrap.Register(&runTestModule{name: "failing", fail: true})
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err == nil {
+		t.Errorf("expected module error")
+		return
+	}
+	if !waiting.stopped {
+		t.Errorf("waiting module should be stopped by the failing module")
+	}
+}
@@ -0,0 +1,162 @@
+package bootstrap
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+)
+
+type signalTestModule struct {
+	beforeClose bool
+	close       bool
+	finished    bool
+	stopped     bool
+	block       bool
+	signals     int
+	sigChan     chan os.Signal
+}
+
+func newSignalTestModule(block bool, signals int) *signalTestModule {
+	return &signalTestModule{
+		block:   block,
+		signals: signals,
+		sigChan: make(chan os.Signal, 2),
+	}
+}
+
+func (m *signalTestModule) RegisterDependencies(a app.App) error {
+	a.AppScope().On(app.BeforeCloseEvent, func(interface{}) error {
+		m.beforeClose = true
+		return nil
+	})
+	a.AppScope().On(app.CloseEvent, func(interface{}) error {
+		m.close = true
+		return nil
+	})
+	return nil
+}
+
+func (m *signalTestModule) InitDependencies(a app.App) error {
+	return nil
+}
+
+func (m *signalTestModule) Run(a app.App) error {
+	go m.sendInterrupts()
+	if m.block {
+		time.Sleep(time.Second)
+		return nil
+	}
+	<-a.AppScope().Context().Done()
+	m.finished = true
+	return nil
+}
+
+func (m *signalTestModule) Stop(a app.App) error {
+	m.stopped = true
+	return nil
+}
+
+// sendInterrupts send the module signals count (at least one) interrupt signals to the bootstrap
+func (m *signalTestModule) sendInterrupts() {
+	for i := 0; i == 0 || i < m.signals; i++ {
+		if i != 0 {
+			time.Sleep(time.Millisecond * 50)
+		}
+		m.sigChan <- os.Interrupt
+	}
+}
+
+func TestBootstrapGracefulShutdown(t *testing.T) {
+	var (
+		mapp   *mockupapp.App
+		err    error
+		module = newSignalTestModule(false, 1)
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrapWithParams(mapp, Params{
+		Signals: module.sigChan,
+	})
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err != nil {
+		t.Error(err)
+		return
+	}
+	if !module.beforeClose || !module.finished || !module.close || !module.stopped {
+		t.Errorf("expected BeforeCloseEvent, finished module, CloseEvent and stop hook (%v, %v, %v, %v)", module.beforeClose, module.finished, module.close, module.stopped)
+	}
+}
+
+func TestBootstrapGracePeriodTimeout(t *testing.T) {
+	var (
+		mapp   *mockupapp.App
+		err    error
+		module = newSignalTestModule(true, 1)
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrapWithParams(mapp, Params{
+		GracePeriod: time.Millisecond * 10,
+		Signals:     module.sigChan,
+	})
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err == nil {
+		t.Errorf("expected grace period timeout error")
+	}
+	if !module.close {
+		t.Errorf("CloseEvent should be triggered after grace period timeout")
+	}
+}
+
+func TestBootstrapSecondSignal(t *testing.T) {
+	var (
+		mapp   *mockupapp.App
+		err    error
+		module = newSignalTestModule(true, 2)
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := NewBootstrapWithParams(mapp, Params{
+		Signals: module.sigChan,
+	})
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Run(); err == nil {
+		t.Errorf("expected forced exit error")
+	}
+	if module.close || module.stopped {
+		t.Errorf("CloseEvent and stop hooks should be skipped after a second signal (%v, %v)", module.close, module.stopped)
+	}
+}
@@ -0,0 +1,24 @@
+package app
+
+// ConfigType is a type of a config value
+type ConfigType string
+
+const (
+	// ConfigString represent a string config value
+	ConfigString ConfigType = "string"
+	// ConfigInt represent an integer config value
+	ConfigInt ConfigType = "int"
+	// ConfigFloat represent a float64 config value
+	ConfigFloat ConfigType = "float"
+	// ConfigBool represent a boolean config value
+	ConfigBool ConfigType = "bool"
+)
+
+// ConfigDefinition describe a config value (a row of config schema)
+type ConfigDefinition struct {
+	Key         string
+	Type        ConfigType
+	Required    bool
+	Default     interface{}
+	Description string
+}
@@ -21,6 +21,8 @@ const (
 	BeforeCloseEvent = iota
 	// CloseEvent is a action run to close application/scope
 	CloseEvent = iota
+	// ConfigChangedEvent is a action run when config values are reloaded (it gets sorted changed keys)
+	ConfigChangedEvent = iota
 
 	// Error is key for error value
 	Error = "error"
@@ -109,6 +111,11 @@ const (
 	// DefaultUInt64Value is a default value for undefined env, configs etc
 	DefaultUInt64Value = 0
 
+	// ConfigSchemaPrefix is a prefix for config definitions keys (in command scope)
+	ConfigSchemaPrefix = "config.schema."
+	// ScopedProviderKey is a scope data key for the scope dependency provider
+	ScopedProviderKey = "_dependency.ScopedProvider"
+
 	// ENVArg is name default environment application argument
 	ENVArg = "env"
 
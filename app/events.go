@@ -0,0 +1,51 @@
+package app
+
+import "sync"
+
+var events = struct {
+	mu    sync.Mutex
+	ids   map[string]int
+	names map[int]string
+	next  int
+}{
+	ids: map[string]int{
+		"kill":           KillEvent,
+		"error":          ErrorEvent,
+		"commit":         CommitEvent,
+		"after_commit":   AfterCommitEvent,
+		"rollback":       RollbackEvent,
+		"after_rollback": AfterRollbackEvent,
+		"before_close":   BeforeCloseEvent,
+		"close":          CloseEvent,
+		"config_changed": ConfigChangedEvent,
+	},
+	next: ConfigChangedEvent + 1,
+}
+
+// RegisterEvent return an unique event ID for a named custom event.
+// The same name always returns the same ID (built-in events are registered by default).
+func RegisterEvent(name string) int {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	if eID, ok := events.ids[name]; ok {
+		return eID
+	}
+	eID := events.next
+	events.next++
+	events.ids[name] = eID
+	events.names = nil
+	return eID
+}
+
+// EventName return name of a registered event (or empty string for unknown event)
+func EventName(eID int) string {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	if events.names == nil {
+		events.names = make(map[int]string, len(events.ids))
+		for name, id := range events.ids {
+			events.names[id] = name
+		}
+	}
+	return events.names[eID]
+}
@@ -3,7 +3,7 @@ package goatapp
 import (
 	"os"
 	"runtime"
-	"strings"
+	"time"
 
 	"github.com/goatcms/goatcore/varutil/goaterr"
 
@@ -16,8 +16,6 @@ import (
 	"github.com/goatcms/goatcore/filesystem"
 	"github.com/goatcms/goatcore/filesystem/disk"
 	"github.com/goatcms/goatcore/filesystem/filespace/diskfs"
-	"github.com/goatcms/goatcore/filesystem/json"
-	"github.com/goatcms/goatcore/varutil/plainmap"
 	"github.com/mitchellh/go-homedir"
 )
 
@@ -25,6 +23,7 @@ import (
 type GoatApp struct {
 	name    string
 	version string
+	env     string
 
 	arguments []string
 
@@ -44,8 +43,16 @@ type GoatApp struct {
 }
 
 const (
-	// ConfigJSONPath is path to main config file
-	ConfigJSONPath = "/config/config_{{env}}.json"
+	// ConfigBasePath is path to base config file without extension (shared by all environments)
+	ConfigBasePath = "/config/config"
+	// ConfigPath is path to main config file without extension
+	ConfigPath = "/config/config_{{env}}"
+	// ConfigJSONPath is path to main JSON config file
+	ConfigJSONPath = ConfigPath + ".json"
+	// ConfigEnvPrefix is a prefix for system environment variables overriding the config
+	ConfigEnvPrefix = "GOAT_"
+	// ConfigArgPrefix is a prefix for arguments overriding the config
+	ConfigArgPrefix = "config."
 )
 
 // NewGoatApp create new app instance
@@ -201,25 +208,15 @@ func (gapp *GoatApp) initConfigScope() error {
 	if deps.Env == "" {
 		deps.Env = app.DefaultEnv
 	}
-	fullmap := make(map[string]interface{})
-	path := strings.Replace(ConfigJSONPath, "{{env}}", deps.Env, -1)
-	if gapp.currentFilespace.IsFile(path) {
-		if err = json.ReadJSON(gapp.currentFilespace, path, &fullmap); err != nil {
-			return err
-		}
-	}
-	plainmap, err := plainmap.RecursiveMapToPlainMap(fullmap)
+	gapp.env = deps.Env
+	plainmap, err := LoadConfig(gapp.currentFilespace, deps.Env, os.Environ(), gapp.argsScope)
 	if err != nil {
 		return err
 	}
-	ds := &scope.DataScope{
-		Data: plainmap,
-	}
-	gapp.configScope = &scope.Scope{
-		EventScope: scope.NewEventScope(),
-		DataScope:  ds,
-		Injector:   ds.Injector(app.ConfigTagName),
-	}
+	gapp.configScope = scope.NewScope(scope.Params{
+		DataScope: scope.NewDataScope(plainmap),
+		Tag:       app.ConfigTagName,
+	})
 	return nil
 }
 
@@ -239,6 +236,17 @@ func (gapp *GoatApp) initAppScope() error {
 	return nil
 }
 
+// WatchConfig start to reload config scope when a config file is changed (the files are checked every interval).
+// It works until the application scope is killed.
+func (gapp *GoatApp) WatchConfig(interval time.Duration) (err error) {
+	var watcher *ConfigWatcher
+	if watcher, err = NewConfigWatcher(gapp, gapp.currentFilespace, gapp.env, os.Environ()); err != nil {
+		return err
+	}
+	go watcher.Watch(gapp.appScope, interval)
+	return nil
+}
+
 // Name return app name
 func (gapp *GoatApp) Name() string {
 	return gapp.name
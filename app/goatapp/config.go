@@ -0,0 +1,165 @@
+package goatapp
+
+import (
+	"strings"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/filesystem"
+	"github.com/goatcms/goatcore/filesystem/json"
+	"github.com/goatcms/goatcore/filesystem/toml"
+	"github.com/goatcms/goatcore/filesystem/yaml"
+	"github.com/goatcms/goatcore/varutil"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+	"github.com/goatcms/goatcore/varutil/plainmap"
+)
+
+// ConfigDecoder read a config file to a multi level map
+type ConfigDecoder func(fs filesystem.Filespace, path string) (map[string]interface{}, error)
+
+// ConfigFormat describe a config file format
+type ConfigFormat struct {
+	Extension string
+	Decode    ConfigDecoder
+}
+
+// ConfigFormats is a list of supported config formats. A config file can exist in a single
+// format only (config.json and config.yaml together is an error). Append the list
+// to support a custom format.
+var ConfigFormats = []ConfigFormat{
+	{Extension: ".json", Decode: DecodeJSONConfig},
+	{Extension: ".yaml", Decode: DecodeYAMLConfig},
+	{Extension: ".yml", Decode: DecodeYAMLConfig},
+	{Extension: ".toml", Decode: DecodeTOMLConfig},
+}
+
+// LoadConfig read config layers and merge them to a single plain map.
+// The layers are (each overrides the previous one):
+//   - base config file (ConfigBasePath + extension)
+//   - environment config file (ConfigPath + extension)
+//   - system environment variables prefixed by ConfigEnvPrefix (GOAT_DB_URL is mapped to db.url)
+//   - arguments prefixed by ConfigArgPrefix (--config.db.url=value is mapped to db.url)
+func LoadConfig(fs filesystem.Filespace, env string, environ []string, args app.DataScope) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	if err = loadConfigFile(result, fs, ConfigBasePath); err != nil {
+		return nil, err
+	}
+	if err = loadConfigFile(result, fs, strings.Replace(ConfigPath, "{{env}}", env, -1)); err != nil {
+		return nil, err
+	}
+	loadConfigEnvs(result, environ)
+	if args != nil {
+		if err = loadConfigArgs(result, args); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// DecodeJSONConfig read a JSON config file
+func DecodeJSONConfig(fs filesystem.Filespace, path string) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	if err = json.ReadJSON(fs, path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DecodeYAMLConfig read a YAML config file. Values are normalized to JSON types.
+func DecodeYAMLConfig(fs filesystem.Filespace, path string) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	if err = yaml.ReadYAML(fs, path, &result); err != nil {
+		return nil, err
+	}
+	return normalizeConfig(result)
+}
+
+// DecodeTOMLConfig read a TOML config file. Values are normalized to JSON types.
+func DecodeTOMLConfig(fs filesystem.Filespace, path string) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	if err = toml.ReadTOML(fs, path, &result); err != nil {
+		return nil, err
+	}
+	return normalizeConfig(result)
+}
+
+// normalizeConfig convert map values to JSON decoder types (numbers to float64 etc)
+// so all config formats produce the same plain map
+func normalizeConfig(in map[string]interface{}) (out map[string]interface{}, err error) {
+	var data string
+	if data, err = varutil.ObjectToJSON(in); err != nil {
+		return nil, err
+	}
+	out = map[string]interface{}{}
+	if err = varutil.ObjectFromJSON(&out, data); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func loadConfigFile(result map[string]interface{}, fs filesystem.Filespace, basePath string) (err error) {
+	var (
+		fullmap map[string]interface{}
+		plain   map[string]interface{}
+		paths   []string
+		decode  ConfigDecoder
+	)
+	for _, format := range ConfigFormats {
+		path := basePath + format.Extension
+		if !fs.IsFile(path) {
+			continue
+		}
+		paths = append(paths, path)
+		decode = format.Decode
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	if len(paths) > 1 {
+		return goaterr.Errorf("config: %s is defined in many formats (%s)", basePath, strings.Join(paths, ", "))
+	}
+	if fullmap, err = decode(fs, paths[0]); err != nil {
+		return err
+	}
+	if plain, err = plainmap.RecursiveMapToPlainMap(fullmap); err != nil {
+		return err
+	}
+	for key, value := range plain {
+		result[key] = value
+	}
+	return nil
+}
+
+func loadConfigEnvs(result map[string]interface{}, environ []string) {
+	for _, row := range environ {
+		index := strings.Index(row, "=")
+		if index == -1 || !strings.HasPrefix(row, ConfigEnvPrefix) {
+			continue
+		}
+		key := row[len(ConfigEnvPrefix):index]
+		if key == "" {
+			continue
+		}
+		key = strings.ToLower(strings.Replace(key, "_", ".", -1))
+		result[key] = row[index+1:]
+	}
+}
+
+func loadConfigArgs(result map[string]interface{}, args app.DataScope) (err error) {
+	var (
+		keys  []string
+		value interface{}
+	)
+	if keys, err = args.Keys(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, ConfigArgPrefix) || len(key) == len(ConfigArgPrefix) {
+			continue
+		}
+		if value, err = args.Get(key); err != nil {
+			return err
+		}
+		result[key[len(ConfigArgPrefix):]] = value
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package goatapp
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/scope/argscope"
+	"github.com/goatcms/goatcore/filesystem/filespace/memfs"
+)
+
+func TestLoadConfigLayers(t *testing.T) {
+	t.Parallel()
+	var (
+		result map[string]interface{}
+		args   app.Scope
+	)
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config.json", []byte(`{"db":{"host":"base","port":"1","user":"base"},"name":"base"}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config_test.json", []byte(`{"db":{"host":"env","port":"2"}}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if args, err = argscope.NewScope([]string{"appname", "--config.db.host=arg"}, app.ArgsTagName); err != nil {
+		t.Error(err)
+		return
+	}
+	environ := []string{"GOAT_DB_PORT=3", "GOAT_DB_HOST=envvar", "OTHER_NAME=other"}
+	if result, err = LoadConfig(fs, "test", environ, args); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := map[string]string{
+		"name":    "base",
+		"db.user": "base",
+		"db.port": "3",
+		"db.host": "arg",
+	}
+	for key, value := range expected {
+		if result[key] != value {
+			t.Errorf("expected %s equals to '%s' and take '%v'", key, value, result[key])
+		}
+	}
+	if len(result) != len(expected) {
+		t.Errorf("expected %d keys and take %v", len(expected), result)
+	}
+}
+
+func TestLoadConfigWithoutFiles(t *testing.T) {
+	t.Parallel()
+	var result map[string]interface{}
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result, err = LoadConfig(fs, "prod", []string{}, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 0 {
+		t.Errorf("expected empty config and take %v", result)
+	}
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	t.Parallel()
+	var (
+		sources = map[string]string{
+			"/config/config_prod.json": `{"db":{"host":"localhost","port":5432,"ssl":true},"name":"app"}`,
+			"/config/config_prod.yaml": "db:\n  host: localhost\n  port: 5432\n  ssl: true\nname: app\n",
+			"/config/config_prod.toml": "name = \"app\"\n[db]\nhost = \"localhost\"\nport = 5432\nssl = true\n",
+		}
+		expected = map[string]interface{}{
+			"name":    "app",
+			"db.host": "localhost",
+			"db.port": float64(5432),
+			"db.ssl":  true,
+		}
+	)
+	for path, content := range sources {
+		fs, err := memfs.NewFilespace()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err = fs.WriteFile(path, []byte(content), 0766); err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := LoadConfig(fs, "prod", []string{}, nil)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if len(result) != len(expected) {
+			t.Errorf("%s: expected %d keys and take %v", path, len(expected), result)
+		}
+		for key, value := range expected {
+			if result[key] != value {
+				t.Errorf("%s: expected %s equals to '%v' (%T) and take '%v' (%T)", path, key, value, value, result[key], result[key])
+			}
+		}
+	}
+}
+
+func TestLoadConfigManyFormatsError(t *testing.T) {
+	t.Parallel()
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config.json", []byte(`{"name":"json"}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config.yaml", []byte("name: yaml\n"), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = LoadConfig(fs, "prod", []string{}, nil); err == nil {
+		t.Errorf("expected error for a config file defined in many formats")
+	}
+}
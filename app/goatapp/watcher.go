@@ -0,0 +1,163 @@
+package goatapp
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/bootstrap"
+	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/filesystem"
+)
+
+// ConfigWatcher reload the config scope when a config file is changed
+type ConfigWatcher struct {
+	app     app.App
+	fs      filesystem.Filespace
+	env     string
+	environ []string
+	stamps  map[string]configStamp
+}
+
+type configStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewConfigWatcher create a new config watcher for config files from fs filespace
+func NewConfigWatcher(a app.App, fs filesystem.Filespace, env string, environ []string) (watcher *ConfigWatcher, err error) {
+	watcher = &ConfigWatcher{
+		app:     a,
+		fs:      fs,
+		env:     env,
+		environ: environ,
+	}
+	if watcher.stamps, err = watcher.readStamps(); err != nil {
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// Reload re-read config layers (if a config file is changed) and update the config scope.
+// The new config is validated by registered config definitions before it is applied
+// (an invalid config is reloaded after the next change of config files).
+// ConfigChangedEvent is triggered on the config scope with the list of changed keys.
+func (watcher *ConfigWatcher) Reload() (changed []string, err error) {
+	var (
+		stamps      map[string]configStamp
+		data        map[string]interface{}
+		configScope = watcher.app.ConfigScope()
+	)
+	if stamps, err = watcher.readStamps(); err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(stamps, watcher.stamps) {
+		return nil, nil
+	}
+	watcher.stamps = stamps
+	if data, err = LoadConfig(watcher.fs, watcher.env, watcher.environ, watcher.app.ArgsScope()); err != nil {
+		return nil, err
+	}
+	if err = bootstrap.ValidateConfigData(watcher.app.CommandScope(), scope.NewDataScope(data)); err != nil {
+		return nil, err
+	}
+	if changed, err = watcher.swap(configScope, data); err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	return changed, configScope.Trigger(app.ConfigChangedEvent, changed)
+}
+
+// swap replace config values atomically. Removed keys are set to nil (nil is an undefined value).
+func (watcher *ConfigWatcher) swap(configScope app.DataScope, data map[string]interface{}) (changed []string, err error) {
+	var (
+		keys    []string
+		current interface{}
+		locker  = configScope.LockData()
+	)
+	defer func() {
+		if err != nil {
+			locker.Rollback()
+			return
+		}
+		if err = locker.Commit(); err != nil {
+			changed = nil
+		}
+	}()
+	if keys, err = locker.Keys(); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if _, ok := data[key]; ok {
+			continue
+		}
+		if current, err = locker.Get(key); err != nil {
+			return nil, err
+		}
+		if current == nil {
+			continue
+		}
+		changed = append(changed, key)
+		if err = locker.Set(key, nil); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range data {
+		if current, err = locker.Get(key); err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(current, value) {
+			continue
+		}
+		changed = append(changed, key)
+		if err = locker.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// Watch check config files periodically until the scope is killed.
+// Reload errors are printed to the application error output and triggered as ErrorEvent on the config scope.
+func (watcher *ConfigWatcher) Watch(scp app.SyncScope, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-scp.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := watcher.Reload(); err != nil {
+				watcher.app.IOContext().IO().Err().Printf("config reload error: %v\n", err)
+				watcher.app.ConfigScope().Trigger(app.ErrorEvent, err)
+			}
+		}
+	}
+}
+
+func (watcher *ConfigWatcher) readStamps() (stamps map[string]configStamp, err error) {
+	var info os.FileInfo
+	stamps = map[string]configStamp{}
+	for _, basePath := range []string{ConfigBasePath, strings.Replace(ConfigPath, "{{env}}", watcher.env, -1)} {
+		for _, format := range ConfigFormats {
+			path := basePath + format.Extension
+			if !watcher.fs.IsFile(path) {
+				continue
+			}
+			if info, err = watcher.fs.Lstat(path); err != nil {
+				return nil, err
+			}
+			stamps[path] = configStamp{
+				modTime: info.ModTime(),
+				size:    info.Size(),
+			}
+		}
+	}
+	return stamps, nil
+}
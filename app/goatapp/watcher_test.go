@@ -0,0 +1,121 @@
+package goatapp
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/filesystem/filespace/memfs"
+)
+
+func TestConfigWatcherReload(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp    *mockupapp.App
+		watcher *ConfigWatcher
+		changed []string
+		events  [][]string
+		value   interface{}
+	)
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config_prod.json", []byte(`{"db":{"host":"localhost","port":"1"},"name":"app"}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	configScope := mapp.ConfigScope()
+	configScope.Set("db.host", "localhost")
+	configScope.Set("db.port", "1")
+	configScope.Set("name", "app")
+	configScope.On(app.ConfigChangedEvent, func(data interface{}) error {
+		events = append(events, data.([]string))
+		return nil
+	})
+	if watcher, err = NewConfigWatcher(mapp, fs, "prod", []string{}); err != nil {
+		t.Error(err)
+		return
+	}
+	// no changes
+	if changed, err = watcher.Reload(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(changed) != 0 || len(events) != 0 {
+		t.Errorf("expected no changes and take %v", changed)
+		return
+	}
+	// change a value, remove a key and add a new one
+	if err = fs.WriteFile("/config/config_prod.json", []byte(`{"db":{"host":"remotehost","port":"1"},"newkey":"v"}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if changed, err = watcher.Reload(); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []string{"db.host", "name", "newkey"}
+	if len(events) != 1 || len(changed) != len(expected) {
+		t.Errorf("expected one event with %v and take %v (events: %v)", expected, changed, events)
+		return
+	}
+	for i, key := range expected {
+		if changed[i] != key || events[0][i] != key {
+			t.Errorf("expected %v and take %v", expected, changed)
+			return
+		}
+	}
+	if value, _ = configScope.Get("db.host"); value != "remotehost" {
+		t.Errorf("expected db.host equals to remotehost and take %v", value)
+	}
+	if value, _ = configScope.Get("name"); value != nil {
+		t.Errorf("expected removed name key and take %v", value)
+	}
+}
+
+func TestConfigWatcherRejectInvalidConfig(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp    *mockupapp.App
+		watcher *ConfigWatcher
+		value   interface{}
+	)
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = app.RegisterConfig(mapp, app.ConfigDefinition{Key: "db.port", Type: app.ConfigInt, Required: true}); err != nil {
+		t.Error(err)
+		return
+	}
+	mapp.ConfigScope().Set("db.port", 1)
+	if watcher, err = NewConfigWatcher(mapp, fs, "prod", []string{}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = fs.WriteFile("/config/config_prod.json", []byte(`{"db":{"port":"not-a-number"}}`), 0766); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = watcher.Reload(); err == nil {
+		t.Errorf("expected validation error")
+		return
+	}
+	if value, _ = mapp.ConfigScope().Get("db.port"); value != 1 {
+		t.Errorf("invalid config should not be applied (db.port is %v)", value)
+	}
+	if _, err = watcher.Reload(); err != nil {
+		t.Errorf("unchanged invalid config shouldn't be reloaded again: %v", err)
+	}
+}
@@ -2,11 +2,15 @@ package app
 
 import (
 	"strings"
+	"sync"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil"
 
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
-// CloseApp close application
+// CloseApp close application. Dependencies created by factories are disposed at the end.
 func CloseApp(a App) error {
 	// close in inverted order (to init)
 	return goaterr.ToError(goaterr.AppendError(nil,
@@ -15,6 +19,7 @@ func CloseApp(a App) error {
 		a.FilespaceScope().Trigger(KillEvent, nil),
 		a.ArgsScope().Trigger(KillEvent, nil),
 		a.EngineScope().Trigger(KillEvent, nil),
+		a.DependencyProvider().Dispose(),
 	))
 }
 
@@ -38,3 +43,66 @@ func RegisterArgument(a App, name string, help string) (err error) {
 	a.CommandScope().Set("help.argument."+name, help)
 	return nil
 }
+
+// RegisterConfig add new config definition to application config schema.
+// The config scope is validated by the schema before modules init dependencies.
+func RegisterConfig(a App, def ConfigDefinition) (err error) {
+	if def.Key == "" {
+		return goaterr.Errorf("RegisterConfig: config key is required")
+	}
+	a.CommandScope().Set(ConfigSchemaPrefix+def.Key, def)
+	return nil
+}
+
+// ScopeDependencyProvider return a dependency provider bound to the scope (child scopes get own providers).
+// It is created on first call and its scoped instances are disposed when the scope is killed or closed.
+func ScopeDependencyProvider(a App, scp Scope) (dp dependency.ScopedProvider, err error) {
+	var (
+		keys []string
+		ins  interface{}
+		ok   bool
+	)
+	locker := scp.LockData()
+	if keys, err = locker.Keys(); err != nil {
+		locker.Commit()
+		return nil, err
+	}
+	if varutil.IsArrContainStr(keys, ScopedProviderKey) {
+		if ins, err = locker.Get(ScopedProviderKey); err != nil {
+			locker.Commit()
+			return nil, err
+		}
+		if dp, ok = ins.(dependency.ScopedProvider); ok {
+			return dp, locker.Commit()
+		}
+	}
+	dp = a.DependencyProvider().CreateScope()
+	if err = locker.Set(ScopedProviderKey, dp); err != nil {
+		locker.Rollback()
+		return nil, err
+	}
+	if err = locker.Commit(); err != nil {
+		return nil, err
+	}
+	var (
+		// listenersMU guards listener IDs (dispose can be run before On returns)
+		listenersMU                 sync.Mutex
+		killListener, closeListener ListenerID
+	)
+	dispose := func(interface{}) error {
+		listenersMU.Lock()
+		scp.Off(killListener)
+		scp.Off(closeListener)
+		listenersMU.Unlock()
+		return dp.Dispose()
+	}
+	listenersMU.Lock()
+	killListener = scp.On(KillEvent, dispose)
+	closeListener = scp.On(CloseEvent, dispose)
+	listenersMU.Unlock()
+	if scp.IsKilled() {
+		// the scope was killed before the listeners were connected
+		return dp, dispose(nil)
+	}
+	return dp, nil
+}
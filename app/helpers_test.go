@@ -0,0 +1,63 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/dependency"
+)
+
+func TestScopeDependencyProviderKilledConcurrently(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		err  error
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 20; i++ {
+		scp := scope.NewScope(scope.Params{})
+		killed := make(chan struct{})
+		go func() {
+			scp.Kill()
+			close(killed)
+		}()
+		if _, err = app.ScopeDependencyProvider(mapp, scp); err != nil {
+			t.Error(err)
+			return
+		}
+		<-killed
+	}
+}
+
+func TestScopeDependencyProviderOfKilledScope(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp *mockupapp.App
+		dp   dependency.ScopedProvider
+		err  error
+	)
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = mapp.DependencyProvider().AddScopedFactory("scoped", func(dp dependency.Provider) (interface{}, error) {
+		return &struct{}{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	scp := scope.NewScope(scope.Params{})
+	scp.Kill()
+	if dp, err = app.ScopeDependencyProvider(mapp, scp); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = dp.Get("scoped"); err == nil {
+		t.Errorf("the provider of a killed scope should be disposed")
+	}
+}
@@ -0,0 +1,75 @@
+package injector
+
+import (
+	"reflect"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// Found is a set of keys injected by an injection chain (grouped by tagname)
+type Found map[string]map[string]bool
+
+// Source mark the tagname as a source of the injection chain
+func (found Found) Source(tagname string) {
+	if found[tagname] == nil {
+		found[tagname] = map[string]bool{}
+	}
+}
+
+// Add mark the key as injected
+func (found Found) Add(tagname, key string) {
+	found.Source(tagname)
+	found[tagname][key] = true
+}
+
+// ChainInjector inject values as a part of an injection chain. Injected keys are added
+// to the found set and default values are set by the chain for keys not found by any injector.
+type ChainInjector interface {
+	InjectChain(obj interface{}, found Found) error
+}
+
+// InjectChain run the injector as a part of the injection chain
+func InjectChain(ins app.Injector, obj interface{}, found Found) error {
+	if chain, ok := ins.(ChainInjector); ok {
+		return chain.InjectChain(obj, found)
+	}
+	return ins.InjectTo(obj)
+}
+
+// InjectTo run the injection chain and set default values (`default=` tag option)
+// of keys not found by any injector of the chain
+func InjectTo(ins ChainInjector, obj interface{}) (err error) {
+	found := Found{}
+	if err = ins.InjectChain(obj, found); err != nil {
+		return err
+	}
+	return SetDefaults(obj, found)
+}
+
+// SetDefaults set default values of keys not found by the chain sources. A default value
+// doesn't overwrite a value set before the injection (non-zero field).
+func SetDefaults(obj interface{}, found Found) error {
+	structValue := reflect.ValueOf(obj).Elem()
+	for i := 0; i < structValue.NumField(); i++ {
+		valueField := structValue.Field(i)
+		structField := structValue.Type().Field(i)
+		for tagname, keys := range found {
+			tagValue := structField.Tag.Get(tagname)
+			if tagValue == "" {
+				continue
+			}
+			tag := ParseTag(tagValue)
+			if !tag.HasDefault || keys[tag.Key] || !valueField.IsZero() {
+				continue
+			}
+			if !valueField.CanSet() {
+				return goaterr.Errorf("injector.SetDefaults: Cannot set %s field value", structField.Name)
+			}
+			if err := SetField(valueField, tag.Default); err != nil {
+				return goaterr.Wrapf("injector.SetDefaults: incorrect %s default value", err, tag.Key)
+			}
+		}
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package injector
 
 import (
 	"reflect"
-	"strings"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/varutil/goaterr"
@@ -22,39 +21,46 @@ func NewMapInjector(tagname string, data map[string]interface{}) app.Injector {
 	})
 }
 
-// InjectTo inject data from all injectors
+// InjectTo inject data from all injectors. String values are converted to a field type
+// and the default value (`default=` tag option) is used for undefined keys.
 func (mi MapInjector) InjectTo(obj interface{}) error {
+	return InjectTo(mi, obj)
+}
+
+// InjectChain inject map data as a part of an injection chain (see ChainInjector)
+func (mi MapInjector) InjectChain(obj interface{}, found Found) error {
+	found.Source(mi.tagname)
 	structValue := reflect.ValueOf(obj).Elem()
 	for i := 0; i < structValue.NumField(); i++ {
-		var isRequired = true
 		valueField := structValue.Field(i)
 		structField := structValue.Type().Field(i)
-		key := structField.Tag.Get(mi.tagname)
-		if key == "" {
+		tagValue := structField.Tag.Get(mi.tagname)
+		if tagValue == "" {
 			continue
 		}
-		if strings.HasPrefix(key, "?") {
-			isRequired = false
-			key = key[1:]
-		}
+		tag := ParseTag(tagValue)
 		if !valueField.IsValid() {
 			return goaterr.Errorf("MapInjector.InjectTo: %s is not valid", structField.Name)
 		}
 		if !valueField.CanSet() {
 			return goaterr.Errorf("MapInjector.InjectTo: Cannot set %s field value", structField.Name)
 		}
-		newValue, ok := mi.data[key]
-		if !ok {
-			if !isRequired {
+		newValue, ok := mi.data[tag.Key]
+		if !ok || newValue == nil {
+			if tag.HasDefault {
+				continue
+			} else if !ok && !tag.Required {
 				continue
+			} else if !ok {
+				return goaterr.Errorf("value for %s is unknown", tag.Key)
+			} else {
+				return goaterr.Errorf("MapInjector.InjectTo: dependency instance can not be nil (%s)", tag.Key)
 			}
-			return goaterr.Errorf("value for %s is unknown", key)
 		}
-		if newValue == nil {
-			return goaterr.Errorf("MapInjector.InjectTo: dependency instance can not be nil (%s)", key)
+		if err := SetField(valueField, newValue); err != nil {
+			return goaterr.Wrapf("MapInjector.InjectTo: incorrect %s value", err, tag.Key)
 		}
-		refValue := reflect.ValueOf(newValue)
-		valueField.Set(refValue)
+		found.Add(mi.tagname, tag.Key)
 	}
 	return nil
 }
@@ -2,6 +2,9 @@ package injector
 
 import (
 	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
 )
 
 type TestInjectableObject struct {
@@ -27,3 +30,115 @@ func TestSimpleInject(t *testing.T) {
 		t.Error("MapInjector didn't inject 'SomeStringValue' to SomeString field")
 	}
 }
+
+func TestInjectDefaultsAndConversions(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Int      int           `tagname:"int"`
+		Float    float64       `tagname:"float"`
+		Bool     bool          `tagname:"?bool,default=true"`
+		Duration time.Duration `tagname:"duration"`
+		Slice    []int         `tagname:"?slice,default=1,2,3"`
+		Strings  []string      `tagname:"strings"`
+		Nested   struct {
+			Name string `json:"name"`
+		} `tagname:"nested"`
+		Missing string `tagname:"?missing"`
+	}
+	injector := NewMultiInjector([]app.Injector{
+		NewMapInjector("tagname", map[string]interface{}{
+			"int":      "12",
+			"float":    float64(1.5),
+			"duration": "1m30s",
+			"strings":  []interface{}{"a", "b"},
+			"nested":   `{"name":"value"}`,
+		}),
+	})
+	if err := injector.InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if object.Int != 12 || object.Float != 1.5 || !object.Bool || object.Duration != 90*time.Second {
+		t.Errorf("incorrect scalar values %+v", object)
+	}
+	if len(object.Slice) != 3 || object.Slice[2] != 3 {
+		t.Errorf("expected default slice [1 2 3] and take %v", object.Slice)
+	}
+	if len(object.Strings) != 2 || object.Strings[1] != "b" {
+		t.Errorf("expected [a b] and take %v", object.Strings)
+	}
+	if object.Nested.Name != "value" {
+		t.Errorf("expected nested struct and take %+v", object.Nested)
+	}
+}
+
+func TestInjectIncorrectValue(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Int int `tagname:"int"`
+	}
+	injector := NewMapInjector("tagname", map[string]interface{}{
+		"int": "not-a-number",
+	})
+	if err := injector.InjectTo(&object); err == nil {
+		t.Errorf("expected conversion error")
+	}
+}
+
+func TestDefaultNotOverwriteValue(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Name string `tagname:"?name,default=default"`
+	}
+	object.Name = "injected"
+	if err := NewMapInjector("tagname", map[string]interface{}{}).InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if object.Name != "injected" {
+		t.Errorf("expected 'injected' and take '%s'", object.Name)
+	}
+}
+
+func TestInjectedZeroValueOverrideDefault(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Silent bool `tagname:"?silent,default=true"`
+		Port   int  `tagname:"?port,default=8080"`
+	}
+	injector := NewMultiInjector([]app.Injector{
+		NewMapInjector("tagname", map[string]interface{}{
+			"silent": false,
+			"port":   "0",
+		}),
+		NewMapInjector("tagname", map[string]interface{}{}),
+	})
+	if err := injector.InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if object.Silent || object.Port != 0 {
+		t.Errorf("injected values must override defaults and take %+v", object)
+	}
+}
+
+func TestInjectIncorrectNumber(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Int  int  `tagname:"int"`
+		Int8 int8 `tagname:"?int8"`
+	}
+	if err := NewMapInjector("tagname", map[string]interface{}{"int": float64(2)}).InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if object.Int != 2 {
+		t.Errorf("expected 2 and take %d", object.Int)
+	}
+	if err := NewMapInjector("tagname", map[string]interface{}{"int": float64(1.5)}).InjectTo(&object); err == nil {
+		t.Errorf("expected an error for a fractional value")
+	}
+	if err := NewMapInjector("tagname", map[string]interface{}{"int": 1, "int8": 300}).InjectTo(&object); err == nil {
+		t.Errorf("expected an overflow error")
+	}
+}
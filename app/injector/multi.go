@@ -14,10 +14,15 @@ func NewMultiInjector(injectors []app.Injector) app.Injector {
 	})
 }
 
-// InjectTo inject data from all injectors
+// InjectTo inject data from all injectors. Default values are set for keys not found by any injector.
 func (mi MultiInjector) InjectTo(obj interface{}) error {
+	return InjectTo(mi, obj)
+}
+
+// InjectChain inject data from all injectors as a part of an injection chain
+func (mi MultiInjector) InjectChain(obj interface{}, found Found) error {
 	for _, injector := range mi.injectors {
-		if err := injector.InjectTo(obj); err != nil {
+		if err := InjectChain(injector, obj, found); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,45 @@
+package injector
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/goatcms/goatcore/varutil/totype"
+)
+
+const defaultOption = ",default="
+
+// Tag is a parsed injection tag
+type Tag struct {
+	Key        string
+	Required   bool
+	Default    string
+	HasDefault bool
+}
+
+// ParseTag parse an injection tag like `?name,default=value`. The "?" prefix marks an optional value.
+// The default option must be the last one (the default value can contain commas, e.g. for slices).
+func ParseTag(tag string) (result Tag) {
+	result.Required = true
+	if index := strings.Index(tag, defaultOption); index != -1 {
+		result.Default = tag[index+len(defaultOption):]
+		result.HasDefault = true
+		tag = tag[:index]
+	}
+	if strings.HasPrefix(tag, "?") {
+		result.Required = false
+		tag = tag[1:]
+	}
+	result.Key = tag
+	return result
+}
+
+// SetField convert the value to the field type (see totype.ToValue) and set it
+func SetField(valueField reflect.Value, value interface{}) (err error) {
+	var converted reflect.Value
+	if converted, err = totype.ToValue(value, valueField.Type()); err != nil {
+		return err
+	}
+	valueField.Set(converted)
+	return nil
+}
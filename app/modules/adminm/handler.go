@@ -0,0 +1,173 @@
+package adminm
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
+)
+
+// HealthCheck is a single health checker result
+type HealthCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Command describe a registered command
+type Command struct {
+	Name string `json:"name"`
+	Help string `json:"help"`
+}
+
+// Task describe a pipeline task status
+type Task struct {
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Done   bool     `json:"done"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Handler serve application health, commands and pipeline tasks
+type Handler struct {
+	app       app.App
+	tasksUnit pipservices.TasksUnit
+	mux       *http.ServeMux
+}
+
+// NewHandler create a new admin HTTP handler. The tasksUnit is optional
+// (the tasks endpoint is unavailable without pipelinem).
+func NewHandler(a app.App, tasksUnit pipservices.TasksUnit) *Handler {
+	handler := &Handler{
+		app:       a,
+		tasksUnit: tasksUnit,
+		mux:       http.NewServeMux(),
+	}
+	handler.mux.HandleFunc("/health", handler.health)
+	handler.mux.HandleFunc("/commands", handler.commands)
+	handler.mux.HandleFunc("/tasks", handler.tasks)
+	return handler
+}
+
+// Handle register an additional endpoint
+func (handler *Handler) Handle(pattern string, h http.Handler) {
+	handler.mux.Handle(pattern, h)
+}
+
+// ServeHTTP serve admin endpoints
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler.mux.ServeHTTP(w, req)
+}
+
+func (handler *Handler) health(w http.ResponseWriter, req *http.Request) {
+	var (
+		commandScope = handler.app.CommandScope()
+		checks       = []HealthCheck{}
+		status       = http.StatusOK
+		keys         []string
+		ins          interface{}
+		err          error
+	)
+	if keys, err = commandScope.Keys(); err != nil {
+		writeError(w, err)
+		return
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, healthPrefix) {
+			continue
+		}
+		if ins, err = commandScope.Get(key); err != nil {
+			writeError(w, err)
+			return
+		}
+		check := HealthCheck{
+			Name: key[len(healthPrefix):],
+			OK:   true,
+		}
+		if check.Message, err = ins.(app.HealthCheckerCallback)(handler.app, handler.app.AppScope()); err != nil {
+			check.OK = false
+			check.Error = err.Error()
+			status = http.StatusServiceUnavailable
+		}
+		checks = append(checks, check)
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"ok":     status == http.StatusOK,
+		"checks": checks,
+	})
+}
+
+func (handler *Handler) commands(w http.ResponseWriter, req *http.Request) {
+	var (
+		commandScope = handler.app.CommandScope()
+		commands     = []Command{}
+		keys         []string
+		ins          interface{}
+		err          error
+	)
+	if keys, err = commandScope.Keys(); err != nil {
+		writeError(w, err)
+		return
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, commandPrefix) {
+			continue
+		}
+		if ins, err = commandScope.Get(key); err != nil {
+			writeError(w, err)
+			return
+		}
+		help, _ := ins.(string)
+		commands = append(commands, Command{
+			Name: key[len(commandPrefix):],
+			Help: help,
+		})
+	}
+	writeJSON(w, http.StatusOK, commands)
+}
+
+func (handler *Handler) tasks(w http.ResponseWriter, req *http.Request) {
+	var tasks = []Task{}
+	if handler.tasksUnit == nil {
+		http.NotFound(w, req)
+		return
+	}
+	for _, manager := range handler.tasksUnit.Managers() {
+		names := manager.Names()
+		sort.Strings(names)
+		for _, name := range names {
+			task, ok := manager.Get(name)
+			if !ok {
+				continue
+			}
+			row := Task{
+				Name:   name,
+				Status: task.Status(),
+				Done:   task.Done(),
+			}
+			for _, err := range task.Errors() {
+				row.Errors = append(row.Errors, err.Error())
+			}
+			tasks = append(tasks, row)
+		}
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusInternalServerError, map[string]string{
+		"error": err.Error(),
+	})
+}
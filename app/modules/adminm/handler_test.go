@@ -0,0 +1,124 @@
+package adminm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/namespaces"
+	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/tasks"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+func newTestApp() (mapp *mockupapp.App, err error) {
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		return nil, err
+	}
+	return mapp, goaterr.ToError(goaterr.AppendError(nil,
+		app.RegisterCommand(mapp, "build", func(app.App, app.IOContext) error { return nil }, "build project"),
+		app.RegisterHealthChecker(mapp, "good", func(app.App, app.Scope) (string, error) {
+			return "good is ok", nil
+		}),
+	))
+}
+
+func TestHandlerHealth(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		result struct {
+			OK     bool          `json:"ok"`
+			Checks []HealthCheck `json:"checks"`
+		}
+	)
+	if mapp, err = newTestApp(); err != nil {
+		t.Error(err)
+		return
+	}
+	handler := NewHandler(mapp, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 status code and take %d", rec.Code)
+	}
+	if err = json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Error(err)
+		return
+	}
+	if !result.OK || len(result.Checks) != 1 || result.Checks[0].Message != "good is ok" {
+		t.Errorf("unexpected health result %s", rec.Body.String())
+	}
+	// failed checker
+	app.RegisterHealthChecker(mapp, "bad", func(app.App, app.Scope) (string, error) {
+		return "bad is broken", goaterr.Errorf("broken")
+	})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 status code and take %d", rec.Code)
+	}
+}
+
+func TestHandlerCommands(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		result []Command
+	)
+	if mapp, err = newTestApp(); err != nil {
+		t.Error(err)
+		return
+	}
+	rec := httptest.NewRecorder()
+	NewHandler(mapp, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/commands", nil))
+	if err = json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 1 || result[0].Name != "build" || result[0].Help != "build project" {
+		t.Errorf("unexpected commands result %s", rec.Body.String())
+	}
+}
+
+func TestHandlerTasks(t *testing.T) {
+	t.Parallel()
+	var (
+		mapp   *mockupapp.App
+		err    error
+		result []Task
+	)
+	if mapp, err = newTestApp(); err != nil {
+		t.Error(err)
+		return
+	}
+	// without pipelinem
+	rec := httptest.NewRecorder()
+	NewHandler(mapp, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 status code without tasks unit and take %d", rec.Code)
+	}
+	// with pipelinem
+	tasksUnit := tasks.NewUnit(tasks.UnitDeps{
+		NamespacesUnit: namespaces.NewUnit(),
+	})
+	rec = httptest.NewRecorder()
+	NewHandler(mapp, tasksUnit).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 status code and take %d", rec.Code)
+	}
+	if err = json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 0 {
+		t.Errorf("expected empty tasks list and take %s", rec.Body.String())
+	}
+	if len(tasksUnit.Managers()) != 0 {
+		t.Errorf("tasks endpoint shouldn't create a task manager")
+	}
+}
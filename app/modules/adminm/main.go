@@ -0,0 +1,17 @@
+// Package adminm provides an HTTP admin module. It serves registered health
+// checkers, commands and pipeline tasks statuses as JSON.
+package adminm
+
+const (
+	// PortArg is an argument to define the admin server port (it overrides config)
+	PortArg = "adminport"
+	// PortConfig is a config key to define the admin server port
+	PortConfig = "admin.port"
+	// HostConfig is a config key to define the admin server listen host
+	HostConfig = "admin.host"
+	// DefaultHost is the default admin server listen host (the server is local only by default)
+	DefaultHost = "127.0.0.1"
+
+	healthPrefix  = "health."
+	commandPrefix = "help.command."
+)
@@ -0,0 +1,99 @@
+package adminm
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
+	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// Module serve admin HTTP endpoints
+type Module struct {
+	handler *Handler
+	server  *http.Server
+	addr    string
+}
+
+// NewModule create new admin module instance
+func NewModule() app.Module {
+	return &Module{}
+}
+
+// RegisterDependencies is init callback to register module dependencies
+func (m *Module) RegisterDependencies(a app.App) error {
+	return goaterr.ToError(goaterr.AppendError(nil,
+		app.RegisterArgument(a, PortArg, "admin HTTP server port (the server is disabled by default)"),
+		app.RegisterConfig(a, app.ConfigDefinition{
+			Key:         PortConfig,
+			Type:        app.ConfigInt,
+			Description: "admin HTTP server port (the server is disabled by default)",
+		}),
+		app.RegisterConfig(a, app.ConfigDefinition{
+			Key:         HostConfig,
+			Type:        app.ConfigString,
+			Default:     DefaultHost,
+			Description: "admin HTTP server listen host",
+		}),
+	))
+}
+
+// InitDependencies is init callback to inject dependencies inside module
+func (m *Module) InitDependencies(a app.App) (err error) {
+	var deps struct {
+		TasksUnit  pipservices.TasksUnit `dependency:"?PipTasksUnit"`
+		Metrics    commservices.Metrics  `dependency:"?CommonMetrics"`
+		ArgPort    string                `argument:"?adminport"`
+		ConfigPort int                   `config:"?admin.port"`
+		ConfigHost string                `config:"?admin.host"`
+	}
+	if err = a.DependencyProvider().InjectTo(&deps); err != nil {
+		return err
+	}
+	m.handler = NewHandler(a, deps.TasksUnit)
+	if deps.Metrics != nil {
+		m.handler.Handle("/metrics", metrics.Handler(deps.Metrics))
+	}
+	if deps.ConfigHost == "" {
+		deps.ConfigHost = DefaultHost
+	}
+	if deps.ArgPort != "" {
+		m.addr = net.JoinHostPort(deps.ConfigHost, deps.ArgPort)
+	} else if deps.ConfigPort != 0 {
+		m.addr = net.JoinHostPort(deps.ConfigHost, strconv.Itoa(deps.ConfigPort))
+	}
+	return nil
+}
+
+// Run start admin HTTP server (if port is defined). It doesn't block application.
+func (m *Module) Run(a app.App) (err error) {
+	var listener net.Listener
+	if m.addr == "" {
+		return nil
+	}
+	if listener, err = net.Listen("tcp", m.addr); err != nil {
+		return goaterr.Wrapf("adminm: listen on %s error", err, m.addr)
+	}
+	m.server = &http.Server{
+		Handler: m.handler,
+	}
+	go func() {
+		if err := m.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			a.AppScope().AppendError(goaterr.Wrapf("adminm: serve on %s error", err, m.addr))
+		}
+	}()
+	return nil
+}
+
+// Stop shutdown admin HTTP server
+func (m *Module) Stop(a app.App) (err error) {
+	if m.server == nil {
+		return nil
+	}
+	return m.server.Shutdown(context.Background())
+}
@@ -0,0 +1,107 @@
+package adminm
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/bootstrap"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/modules/commonm"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
+	"github.com/goatcms/goatcore/goatnet"
+)
+
+func TestModuleServeHealth(t *testing.T) {
+	t.Parallel()
+	var (
+		err    error
+		mapp   *mockupapp.App
+		port   int
+		module = NewModule()
+		resp   *http.Response
+	)
+	if port, err = goatnet.GetFreePort(); err != nil {
+		t.Error(err)
+		return
+	}
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{
+		Args: []string{"appname", "--adminport=" + strconv.Itoa(port)},
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = module.Run(mapp); err != nil {
+		t.Error(err)
+		return
+	}
+	defer module.(app.StoppableModule).Stop(mapp)
+	if resp, err = http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/health"); err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 status code and take %d", resp.StatusCode)
+	}
+}
+
+func TestModuleServeMetrics(t *testing.T) {
+	t.Parallel()
+	var (
+		err    error
+		mapp   *mockupapp.App
+		port   int
+		module = NewModule()
+		resp   *http.Response
+	)
+	if port, err = goatnet.GetFreePort(); err != nil {
+		t.Error(err)
+		return
+	}
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{
+		Args: []string{"appname", "--adminport=" + strconv.Itoa(port)},
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(commonm.NewModule()); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Register(module); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = module.Run(mapp); err != nil {
+		t.Error(err)
+		return
+	}
+	defer module.(app.StoppableModule).Stop(mapp)
+	if resp, err = http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/metrics"); err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 status code and take %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != metrics.ContentType {
+		t.Errorf("incorrect content type %s", resp.Header.Get("Content-Type"))
+	}
+}
@@ -0,0 +1,76 @@
+package commservices
+
+import (
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+const (
+	// LogFieldsKey is a scope key for scope log fields
+	LogFieldsKey = "logger_fields"
+)
+
+// LogLevel is a log message priority
+type LogLevel int
+
+const (
+	// DebugLevel is a level for debug messages
+	DebugLevel LogLevel = iota
+	// InfoLevel is a level for information messages
+	InfoLevel
+	// WarnLevel is a level for warnings
+	WarnLevel
+	// ErrorLevel is a level for errors
+	ErrorLevel
+)
+
+// LogFields is a set of structured log data
+type LogFields map[string]interface{}
+
+// Logger is a leveled and structured logger
+type Logger interface {
+	Debug(msg string, fields LogFields)
+	Info(msg string, fields LogFields)
+	Warn(msg string, fields LogFields)
+	Error(msg string, fields LogFields)
+	// Level return minimum level of printed messages
+	Level() LogLevel
+	// With return child logger which attach the fields to each message
+	With(fields LogFields) Logger
+	// ForScope return child logger which attach scope metadata (task name, namespace etc.) to each message
+	ForScope(scp app.DataScope) (logger Logger, err error)
+}
+
+// DefineLogScopeFields add log fields to a scope. The fields are attached to messages
+// of loggers created by Logger.ForScope for the scope (and its child scopes).
+func DefineLogScopeFields(scp app.DataScope, fields LogFields) (err error) {
+	var (
+		current LogFields
+		result  = LogFields{}
+	)
+	if current, err = LogScopeFields(scp); err != nil {
+		return err
+	}
+	for key, value := range current {
+		result[key] = value
+	}
+	for key, value := range fields {
+		result[key] = value
+	}
+	return scp.Set(LogFieldsKey, result)
+}
+
+// LogScopeFields return log fields defined for a scope (nil if the fields are undefined)
+func LogScopeFields(scp app.DataScope) (fields LogFields, err error) {
+	var (
+		ins interface{}
+		ok  bool
+	)
+	if ins, err = scp.Get(LogFieldsKey); err != nil || ins == nil {
+		return nil, err
+	}
+	if fields, ok = ins.(LogFields); !ok {
+		return nil, goaterr.Errorf("logger: %s scope value must be commservices.LogFields (take %T)", LogFieldsKey, ins)
+	}
+	return fields, nil
+}
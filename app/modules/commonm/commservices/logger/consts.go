@@ -0,0 +1,12 @@
+package logger
+
+const (
+	// TextFormat print logs as plain text lines (key=value fields)
+	TextFormat = "text"
+	// JSONFormat print logs as JSON lines
+	JSONFormat = "json"
+	// timeFormat is a timestamp format
+	timeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
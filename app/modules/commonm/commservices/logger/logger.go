@@ -0,0 +1,251 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// Params describe logger
+type Params struct {
+	Output app.Output
+	Level  commservices.LogLevel
+	Format string
+}
+
+// shared contains logger data shared by child loggers
+type shared struct {
+	mu     sync.Mutex
+	out    app.Output
+	level  commservices.LogLevel
+	format string
+	now    func() time.Time
+}
+
+// Logger is a leveled and structured logger
+type Logger struct {
+	shared *shared
+	fields commservices.LogFields
+}
+
+// NewLogger create a new Logger instance
+func NewLogger(params Params) (logger *Logger, err error) {
+	if params.Output == nil {
+		return nil, goaterr.Errorf("logger: output is required")
+	}
+	if params.Format == "" {
+		params.Format = TextFormat
+	}
+	if params.Format != TextFormat && params.Format != JSONFormat {
+		return nil, goaterr.Errorf("logger: unknown %s format (expected %s or %s)", params.Format, TextFormat, JSONFormat)
+	}
+	return &Logger{
+		shared: &shared{
+			out:    params.Output,
+			level:  params.Level,
+			format: params.Format,
+			now:    time.Now,
+		},
+		fields: commservices.LogFields{},
+	}, nil
+}
+
+// Factory create a logger instance. The level is defined by --loglevel argument
+// or logger.level config value and the format by --logformat argument or logger.format config.
+func Factory(dp dependency.Provider) (ri interface{}, err error) {
+	var (
+		deps struct {
+			Output       app.Output `dependency:"ErrorService"`
+			ArgLevel     string     `argument:"?loglevel"`
+			ArgFormat    string     `argument:"?logformat"`
+			ConfigLevel  string     `config:"?logger.level"`
+			ConfigFormat string     `config:"?logger.format"`
+		}
+		params Params
+		logger *Logger
+	)
+	if err = dp.InjectTo(&deps); err != nil {
+		return nil, err
+	}
+	params.Output = deps.Output
+	params.Format = firstNotEmpty(deps.ArgFormat, deps.ConfigFormat)
+	if params.Level, err = ParseLevel(firstNotEmpty(deps.ArgLevel, deps.ConfigLevel, levelNames[commservices.InfoLevel])); err != nil {
+		return nil, err
+	}
+	if logger, err = NewLogger(params); err != nil {
+		return nil, err
+	}
+	return commservices.Logger(logger), nil
+}
+
+// ParseLevel convert level name (debug, info, warn or error) to LogLevel
+func ParseLevel(name string) (level commservices.LogLevel, err error) {
+	name = strings.ToLower(name)
+	for i, levelName := range levelNames {
+		if levelName == name {
+			return commservices.LogLevel(i), nil
+		}
+	}
+	return 0, goaterr.Errorf("logger: unknown %s level (expected one of %s)", name, strings.Join(levelNames, ", "))
+}
+
+// Debug print debug message
+func (logger *Logger) Debug(msg string, fields commservices.LogFields) {
+	logger.log(commservices.DebugLevel, msg, fields)
+}
+
+// Info print information message
+func (logger *Logger) Info(msg string, fields commservices.LogFields) {
+	logger.log(commservices.InfoLevel, msg, fields)
+}
+
+// Warn print warning message
+func (logger *Logger) Warn(msg string, fields commservices.LogFields) {
+	logger.log(commservices.WarnLevel, msg, fields)
+}
+
+// Error print error message
+func (logger *Logger) Error(msg string, fields commservices.LogFields) {
+	logger.log(commservices.ErrorLevel, msg, fields)
+}
+
+// Level return minimum level of printed messages
+func (logger *Logger) Level() commservices.LogLevel {
+	return logger.shared.level
+}
+
+// With return child logger which attach the fields to each message
+func (logger *Logger) With(fields commservices.LogFields) commservices.Logger {
+	result := commservices.LogFields{}
+	for key, value := range logger.fields {
+		result[key] = value
+	}
+	for key, value := range fields {
+		result[key] = value
+	}
+	return &Logger{
+		shared: logger.shared,
+		fields: result,
+	}
+}
+
+// ForScope return child logger which attach scope fields (see commservices.DefineLogScopeFields) to each message
+func (logger *Logger) ForScope(scp app.DataScope) (result commservices.Logger, err error) {
+	var fields commservices.LogFields
+	if fields, err = commservices.LogScopeFields(scp); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return logger, nil
+	}
+	return logger.With(fields), nil
+}
+
+func (logger *Logger) log(level commservices.LogLevel, msg string, fields commservices.LogFields) {
+	var (
+		line   string
+		shared = logger.shared
+		all    = commservices.LogFields{}
+	)
+	if level < shared.level {
+		return
+	}
+	for key, value := range logger.fields {
+		all[key] = value
+	}
+	for key, value := range fields {
+		all[key] = value
+	}
+	timestamp := shared.now().Format(timeFormat)
+	if shared.format == JSONFormat {
+		line = formatJSON(timestamp, levelNames[level], msg, all)
+	} else {
+		line = formatText(timestamp, levelNames[level], msg, all)
+	}
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	shared.out.Write([]byte(line))
+}
+
+func formatText(timestamp, level, msg string, fields commservices.LogFields) string {
+	var sb strings.Builder
+	sb.WriteString(timestamp)
+	sb.WriteString(" ")
+	sb.WriteString(strings.ToUpper(level))
+	sb.WriteString(" ")
+	sb.WriteString(strconv.Quote(msg))
+	for _, key := range sortedKeys(fields) {
+		sb.WriteString(" ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(formatTextValue(fields[key]))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func formatTextValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func formatJSON(timestamp, level, msg string, fields commservices.LogFields) string {
+	row := map[string]interface{}{}
+	for key, value := range fields {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		row[key] = value
+	}
+	row["time"] = timestamp
+	row["level"] = level
+	row["msg"] = msg
+	data, err := json.Marshal(row)
+	if err != nil {
+		data, _ = json.Marshal(map[string]interface{}{
+			"time":  timestamp,
+			"level": level,
+			"msg":   msg,
+			"error": "logger: fields marshal error: " + err.Error(),
+		})
+	}
+	return string(data) + "\n"
+}
+
+func sortedKeys(fields commservices.LogFields) (keys []string) {
+	keys = make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app/gio"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+func newTestLogger(level commservices.LogLevel, format string) (logger *Logger, buf *bytes.Buffer, err error) {
+	buf = &bytes.Buffer{}
+	if logger, err = NewLogger(Params{
+		Output: gio.NewOutput(buf),
+		Level:  level,
+		Format: format,
+	}); err != nil {
+		return nil, nil, err
+	}
+	logger.shared.now = func() time.Time {
+		return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	return logger, buf, nil
+}
+
+func TestLoggerTextFormat(t *testing.T) {
+	t.Parallel()
+	logger, buf, err := newTestLogger(commservices.InfoLevel, TextFormat)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	logger.Debug("skipped", nil)
+	logger.With(commservices.LogFields{
+		"module": "test",
+	}).Warn("some message", commservices.LogFields{
+		"count": 2,
+		"error": goaterr.Errorf("some error"),
+	})
+	expected := "2020-01-02T03:04:05.000Z WARN \"some message\" count=2 error=\"some error\" module=test\n"
+	if buf.String() != expected {
+		t.Errorf("expected %s and take %s", expected, buf.String())
+	}
+}
+
+func TestLoggerJSONFormat(t *testing.T) {
+	t.Parallel()
+	logger, buf, err := newTestLogger(commservices.DebugLevel, JSONFormat)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	logger.Debug("some message", commservices.LogFields{
+		"count": 2,
+	})
+	expected := `{"count":2,"level":"debug","msg":"some message","time":"2020-01-02T03:04:05.000Z"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected %s and take %s", expected, buf.String())
+	}
+}
+
+func TestLoggerForScope(t *testing.T) {
+	t.Parallel()
+	var (
+		parentScope = scope.NewScope(scope.Params{})
+		childScope  = scope.NewChildScope(parentScope, scope.ChildParams{})
+		scpLogger   commservices.Logger
+	)
+	defer childScope.Close()
+	logger, buf, err := newTestLogger(commservices.DebugLevel, TextFormat)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = commservices.DefineLogScopeFields(parentScope, commservices.LogFields{"namespace": "ns"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = commservices.DefineLogScopeFields(childScope, commservices.LogFields{"task": "build"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if scpLogger, err = logger.ForScope(childScope); err != nil {
+		t.Error(err)
+		return
+	}
+	scpLogger.Info("msg", nil)
+	if !strings.Contains(buf.String(), "namespace=ns task=build") {
+		t.Errorf("expected scope fields and take %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+	var (
+		level commservices.LogLevel
+		err   error
+	)
+	if level, err = ParseLevel("WARN"); err != nil {
+		t.Error(err)
+		return
+	}
+	if level != commservices.WarnLevel {
+		t.Errorf("expected warn level and take %v", level)
+	}
+	if _, err = ParseLevel("unknown"); err == nil {
+		t.Errorf("expected error for unknown level")
+	}
+}
+
+func TestLoggerIncorrectScopeFields(t *testing.T) {
+	t.Parallel()
+	scp := scope.NewDataScope(map[string]interface{}{
+		commservices.LogFieldsKey: "incorrect",
+	})
+	logger, _, err := newTestLogger(commservices.DebugLevel, TextFormat)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = logger.ForScope(scp); err == nil {
+		t.Errorf("ForScope should return an error for incorrect scope fields")
+	}
+	if err = commservices.DefineLogScopeFields(scp, commservices.LogFields{"task": "build"}); err == nil {
+		t.Errorf("DefineLogScopeFields should return an error for incorrect scope fields")
+	}
+}
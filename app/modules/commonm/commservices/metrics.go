@@ -0,0 +1,34 @@
+package commservices
+
+import "io"
+
+// MetricLabels is a set of metric labels (dimensions)
+type MetricLabels map[string]string
+
+// Counter is a metric which value only increase
+type Counter interface {
+	Inc()
+	Add(delta float64)
+}
+
+// Gauge is a metric which value can go up and down
+type Gauge interface {
+	Set(value float64)
+	Add(delta float64)
+}
+
+// Histogram count observations in buckets
+type Histogram interface {
+	Observe(value float64)
+}
+
+// Metrics collect application metrics. A metric is created on the first use.
+// Metrics with the same name must have the same type.
+type Metrics interface {
+	Counter(name, help string, labels MetricLabels) Counter
+	Gauge(name, help string, labels MetricLabels) Gauge
+	// Histogram return a histogram (buckets are used only when the metric is created)
+	Histogram(name, help string, labels MetricLabels, buckets []float64) Histogram
+	// WritePrometheus write all metrics in Prometheus text exposition format
+	WritePrometheus(w io.Writer) (err error)
+}
@@ -0,0 +1,18 @@
+package metrics
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+
+	// ContentType is the Prometheus text exposition format content type
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	// StatusSuccess is a status label value for succeeded operations
+	StatusSuccess = "success"
+	// StatusError is a status label value for failed operations
+	StatusError = "error"
+)
+
+// DefaultBuckets are default histogram buckets (in seconds)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/filesystem"
+)
+
+// Handler return HTTP handler serving metrics in Prometheus text format
+func Handler(metrics commservices.Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		buf := &bytes.Buffer{}
+		if err := metrics.WritePrometheus(buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		w.Write(buf.Bytes())
+	})
+}
+
+// WriteFile write metrics in Prometheus text format to a file
+// (it can be used with node exporter textfile collector)
+func WriteFile(metrics commservices.Metrics, fs filesystem.Filespace, path string) (err error) {
+	buf := &bytes.Buffer{}
+	if err = metrics.WritePrometheus(buf); err != nil {
+		return err
+	}
+	return fs.WriteFile(path, buf.Bytes(), filesystem.DefaultUnixFileMode)
+}
+
+// Status return a status label value for an operation result
+func Status(err error) string {
+	if err != nil {
+		return StatusError
+	}
+	return StatusSuccess
+}
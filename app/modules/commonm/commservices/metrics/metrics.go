@@ -0,0 +1,265 @@
+package metrics
+
+import (
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/dependency"
+)
+
+// Metrics is a thread-safe metrics registry
+type Metrics struct {
+	mu       sync.RWMutex
+	families map[string]*family
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	mu      sync.Mutex
+	labels  string
+	value   float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+	buckets []float64
+}
+
+// NewMetrics create a new Metrics instance
+func NewMetrics() *Metrics {
+	return &Metrics{
+		families: map[string]*family{},
+	}
+}
+
+// Factory create a Metrics instance
+func Factory(dp dependency.Provider) (ri interface{}, err error) {
+	return commservices.Metrics(NewMetrics()), nil
+}
+
+// Counter return a counter
+func (metrics *Metrics) Counter(name, help string, labels commservices.MetricLabels) commservices.Counter {
+	return counter{metrics.get(name, help, counterType, labels, nil)}
+}
+
+// Gauge return a gauge
+func (metrics *Metrics) Gauge(name, help string, labels commservices.MetricLabels) commservices.Gauge {
+	return gauge{metrics.get(name, help, gaugeType, labels, nil)}
+}
+
+// Histogram return a histogram. DefaultBuckets are used if buckets are empty.
+func (metrics *Metrics) Histogram(name, help string, labels commservices.MetricLabels, buckets []float64) commservices.Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	return histogram{metrics.get(name, help, histogramType, labels, buckets)}
+}
+
+func (metrics *Metrics) get(name, help, kind string, labels commservices.MetricLabels, buckets []float64) *series {
+	var (
+		key = formatLabels(labels)
+		f   *family
+		s   *series
+		ok  bool
+	)
+	metrics.mu.RLock()
+	if f, ok = metrics.families[name]; ok && f.kind == kind {
+		if s, ok = f.series[key]; ok {
+			metrics.mu.RUnlock()
+			return s
+		}
+	}
+	metrics.mu.RUnlock()
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if f, ok = metrics.families[name]; !ok {
+		if kind == histogramType {
+			buckets = append([]float64{}, buckets...)
+			sort.Float64s(buckets)
+		}
+		f = &family{
+			name:    name,
+			help:    help,
+			kind:    kind,
+			buckets: buckets,
+			series:  map[string]*series{},
+		}
+		metrics.families[name] = f
+	}
+	if f.kind != kind {
+		// incorrect usage returns a detached series (it is not exposed)
+		return newSeries(key, buckets)
+	}
+	if s, ok = f.series[key]; !ok {
+		s = newSeries(key, f.buckets)
+		f.series[key] = s
+	}
+	return s
+}
+
+func newSeries(labels string, buckets []float64) *series {
+	return &series{
+		labels:  labels,
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)),
+	}
+}
+
+// WritePrometheus write all metrics in Prometheus text exposition format
+func (metrics *Metrics) WritePrometheus(w io.Writer) (err error) {
+	var sb strings.Builder
+	metrics.mu.RLock()
+	names := make([]string, 0, len(metrics.families))
+	for name := range metrics.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		metrics.families[name].write(&sb)
+	}
+	metrics.mu.RUnlock()
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (f *family) write(sb *strings.Builder) {
+	if f.help != "" {
+		sb.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+	}
+	sb.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		s.mu.Lock()
+		if f.kind != histogramType {
+			sb.WriteString(f.name + wrapLabels(s.labels) + " " + formatFloat(s.value) + "\n")
+			s.mu.Unlock()
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range s.buckets {
+			cumulative += s.counts[i]
+			sb.WriteString(f.name + "_bucket" + wrapLabels(joinLabels(s.labels, `le="`+formatFloat(bound)+`"`)) + " " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		sb.WriteString(f.name + "_bucket" + wrapLabels(joinLabels(s.labels, `le="+Inf"`)) + " " + strconv.FormatUint(s.count, 10) + "\n")
+		sb.WriteString(f.name + "_sum" + wrapLabels(s.labels) + " " + formatFloat(s.sum) + "\n")
+		sb.WriteString(f.name + "_count" + wrapLabels(s.labels) + " " + strconv.FormatUint(s.count, 10) + "\n")
+		s.mu.Unlock()
+	}
+}
+
+type counter struct {
+	s *series
+}
+
+func (c counter) Inc() {
+	c.Add(1)
+}
+
+func (c counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.s.mu.Lock()
+	c.s.value += delta
+	c.s.mu.Unlock()
+}
+
+type gauge struct {
+	s *series
+}
+
+func (g gauge) Set(value float64) {
+	g.s.mu.Lock()
+	g.s.value = value
+	g.s.mu.Unlock()
+}
+
+func (g gauge) Add(delta float64) {
+	g.s.mu.Lock()
+	g.s.value += delta
+	g.s.mu.Unlock()
+}
+
+type histogram struct {
+	s *series
+}
+
+func (h histogram) Observe(value float64) {
+	h.s.mu.Lock()
+	defer h.s.mu.Unlock()
+	for i, bound := range h.s.buckets {
+		if value <= bound {
+			h.s.counts[i]++
+			break
+		}
+	}
+	h.s.sum += value
+	h.s.count++
+}
+
+func formatLabels(labels commservices.MetricLabels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([]string, len(keys))
+	for i, key := range keys {
+		rows[i] = key + `="` + escapeLabel(labels[key]) + `"`
+	}
+	return strings.Join(rows, ",")
+}
+
+func joinLabels(labels, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func escapeLabel(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return strings.Replace(s, `"`, `\"`, -1)
+}
+
+func escapeHelp(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return strings.Replace(s, "\n", `\n`, -1)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
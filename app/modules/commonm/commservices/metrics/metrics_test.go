@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/filesystem/filespace/memfs"
+)
+
+func TestCounterAndGauge(t *testing.T) {
+	t.Parallel()
+	metrics := NewMetrics()
+	metrics.Counter("goat_test_total", "Test counter", commservices.MetricLabels{
+		"status": "success",
+	}).Inc()
+	metrics.Counter("goat_test_total", "Test counter", commservices.MetricLabels{
+		"status": "success",
+	}).Add(2)
+	metrics.Counter("goat_test_total", "Test counter", commservices.MetricLabels{
+		"status": "error",
+	}).Inc()
+	metrics.Gauge("goat_test_gauge", "", nil).Set(1.5)
+	buf := &bytes.Buffer{}
+	if err := metrics.WritePrometheus(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "# TYPE goat_test_gauge gauge\n" +
+		"goat_test_gauge 1.5\n" +
+		"# HELP goat_test_total Test counter\n" +
+		"# TYPE goat_test_total counter\n" +
+		"goat_test_total{status=\"error\"} 1\n" +
+		"goat_test_total{status=\"success\"} 3\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ntake:\n%s", expected, buf.String())
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	metrics := NewMetrics()
+	h := metrics.Histogram("goat_test_seconds", "Test histogram", commservices.MetricLabels{
+		"command": "run",
+	}, []float64{1, 0.1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+	buf := &bytes.Buffer{}
+	if err := metrics.WritePrometheus(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "# HELP goat_test_seconds Test histogram\n" +
+		"# TYPE goat_test_seconds histogram\n" +
+		"goat_test_seconds_bucket{command=\"run\",le=\"0.1\"} 1\n" +
+		"goat_test_seconds_bucket{command=\"run\",le=\"1\"} 2\n" +
+		"goat_test_seconds_bucket{command=\"run\",le=\"+Inf\"} 3\n" +
+		"goat_test_seconds_sum{command=\"run\"} 3.55\n" +
+		"goat_test_seconds_count{command=\"run\"} 3\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ntake:\n%s", expected, buf.String())
+	}
+}
+
+func TestLabelsEscaping(t *testing.T) {
+	t.Parallel()
+	metrics := NewMetrics()
+	metrics.Counter("goat_test_total", "", commservices.MetricLabels{
+		"command": "a\"b\\c\nd",
+	}).Inc()
+	buf := &bytes.Buffer{}
+	if err := metrics.WritePrometheus(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(buf.String(), `goat_test_total{command="a\"b\\c\nd"} 1`) {
+		t.Errorf("incorrect escaping: %s", buf.String())
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	metrics := NewMetrics()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				metrics.Counter("goat_test_total", "", nil).Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	buf := &bytes.Buffer{}
+	if err := metrics.WritePrometheus(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(buf.String(), "goat_test_total 1000\n") {
+		t.Errorf("expected 1000 and take %s", buf.String())
+	}
+}
+
+func TestHandlerAndWriteFile(t *testing.T) {
+	t.Parallel()
+	metrics := NewMetrics()
+	metrics.Counter("goat_test_total", "", nil).Inc()
+	rec := httptest.NewRecorder()
+	Handler(metrics).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 and take %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("incorrect content type %s", rec.Header().Get("Content-Type"))
+	}
+	if rec.Body.String() != "# TYPE goat_test_total counter\ngoat_test_total 1\n" {
+		t.Errorf("incorrect body %s", rec.Body.String())
+	}
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = WriteFile(metrics, fs, "/metrics.prom"); err != nil {
+		t.Error(err)
+		return
+	}
+	data, err := fs.ReadFile("/metrics.prom")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(data) != rec.Body.String() {
+		t.Errorf("expected %s and take %s", rec.Body.String(), data)
+	}
+}
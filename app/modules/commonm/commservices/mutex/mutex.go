@@ -3,6 +3,7 @@ package mutex
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/dependency"
@@ -12,6 +13,7 @@ import (
 type SharedMutex struct {
 	mutexesMU sync.RWMutex
 	mutexes   map[string]*sync.RWMutex
+	metrics   commservices.Metrics
 }
 
 // NewSharedMutex create new SharedMutex instance
@@ -23,7 +25,15 @@ func NewSharedMutex() *SharedMutex {
 
 // SharedMutexFactory create a SharedMutex instance
 func SharedMutexFactory(dp dependency.Provider) (ri interface{}, err error) {
-	return commservices.SharedMutex(NewSharedMutex()), nil
+	var deps struct {
+		Metrics commservices.Metrics `dependency:"?CommonMetrics"`
+	}
+	if err = dp.InjectTo(&deps); err != nil {
+		return nil, err
+	}
+	sharedMutex := NewSharedMutex()
+	sharedMutex.metrics = deps.Metrics
+	return commservices.SharedMutex(sharedMutex), nil
 }
 
 // Lock resources
@@ -34,8 +44,9 @@ func SharedMutexFactory(dp dependency.Provider) (ri interface{}, err error) {
 // The function provide lock ordering inside.
 func (sharedMutex *SharedMutex) Lock(resources commservices.LockMap) (handler commservices.UnlockHandler) {
 	var (
-		list = make([]mutexRow, len(resources))
-		i    = 0
+		list  = make([]mutexRow, len(resources))
+		i     = 0
+		start = time.Now()
 	)
 	for name, value := range resources {
 		list[i].Name = name
@@ -53,6 +64,9 @@ func (sharedMutex *SharedMutex) Lock(resources commservices.LockMap) (handler co
 			mu.Lock()
 		}
 	}
+	if sharedMutex.metrics != nil {
+		sharedMutex.metrics.Histogram("goat_shared_mutex_wait_seconds", "Time spent waiting for shared mutex resources", nil, nil).Observe(time.Since(start).Seconds())
+	}
 	return &unlockHandler{
 		list:        list,
 		sharedMutex: sharedMutex,
@@ -7,4 +7,8 @@ const (
 	WaitManagerService = "CommonWaitManager"
 	// EnvironmentsUnitService is a service key
 	EnvironmentsUnitService = "CommonEnvironmentsUnit"
+	// LoggerService is a service key
+	LoggerService = "CommonLogger"
+	// MetricsService is a service key
+	MetricsService = "CommonMetrics"
 )
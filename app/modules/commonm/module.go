@@ -4,8 +4,11 @@ import (
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices/envs"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/logger"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices/mutex"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices/waits"
+	"github.com/goatcms/goatcore/dependency"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -22,8 +25,17 @@ func (m *Module) RegisterDependencies(a app.App) error {
 	dp := a.DependencyProvider()
 	return goaterr.ToError(goaterr.AppendError(nil,
 		dp.AddDefaultFactory(commservices.SharedMutexService, mutex.SharedMutexFactory),
+		dependency.DeclareType[commservices.SharedMutex](dp, commservices.SharedMutexService),
 		dp.AddDefaultFactory(commservices.WaitManagerService, waits.WaitManagerFactory),
+		dependency.DeclareType[commservices.WaitManager](dp, commservices.WaitManagerService),
 		dp.AddDefaultFactory(commservices.EnvironmentsUnitService, envs.UnitFactory),
+		dependency.DeclareType[commservices.EnvironmentsUnit](dp, commservices.EnvironmentsUnitService),
+		dp.AddDefaultFactory(commservices.LoggerService, logger.Factory),
+		dependency.DeclareType[commservices.Logger](dp, commservices.LoggerService),
+		dp.AddDefaultFactory(commservices.MetricsService, metrics.Factory),
+		dependency.DeclareType[commservices.Metrics](dp, commservices.MetricsService),
+		app.RegisterArgument(a, "loglevel", "minimum level of logs (debug, info, warn or error)"),
+		app.RegisterArgument(a, "logformat", "logs format (text or json)"),
 	))
 }
 
@@ -39,6 +39,8 @@ func TestModule(t *testing.T) {
 		SharedMutex      commservices.SharedMutex      `dependency:"CommonSharedMutex"`
 		WaitManager      commservices.WaitManager      `dependency:"CommonWaitManager"`
 		EnvironmentsUnit commservices.EnvironmentsUnit `dependency:"CommonEnvironmentsUnit"`
+		Logger           commservices.Logger           `dependency:"CommonLogger"`
+		Metrics          commservices.Metrics          `dependency:"CommonMetrics"`
 	}
 	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
 		t.Error(err)
@@ -2,9 +2,11 @@ package ocm
 
 import (
 	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/app/modules/ocm/ocservices"
 	"github.com/goatcms/goatcore/app/modules/ocm/ocservices/dcmd"
 	"github.com/goatcms/goatcore/app/modules/ocm/ocservices/ocmanager"
+	"github.com/goatcms/goatcore/dependency"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -21,6 +23,7 @@ func (m *Module) RegisterDependencies(a app.App) error {
 	dp := a.DependencyProvider()
 	return goaterr.ToError(goaterr.AppendError(nil,
 		dp.AddDefaultFactory(ocservices.OCManagerService, ocmanager.ManagerFactory),
+		dependency.DeclareType[ocservices.Manager](dp, ocservices.OCManagerService),
 		app.RegisterHealthChecker(a, "container", ContainerHealthChecker),
 	))
 }
@@ -29,8 +32,9 @@ func (m *Module) RegisterDependencies(a app.App) error {
 func (m *Module) InitDependencies(a app.App) (err error) {
 	var (
 		deps struct {
-			Manager       ocservices.Manager `dependency:"OCManager"`
-			DefaultEngine string             `argument:"?oc.engime"`
+			Manager       ocservices.Manager   `dependency:"OCManager"`
+			DefaultEngine string               `argument:"?oc.engime"`
+			Metrics       commservices.Metrics `dependency:"?CommonMetrics"`
 		}
 		dockerEngine ocservices.Engine
 		podmanEngine ocservices.Engine
@@ -39,14 +43,14 @@ func (m *Module) InitDependencies(a app.App) (err error) {
 		return err
 	}
 	if hasDocker {
-		dockerEngine = dcmd.NewEngine("docker")
+		dockerEngine = dcmd.NewInstrumentedEngine("docker", deps.Metrics)
 		if err = deps.Manager.AddEngine(ocservices.DockerEngine, dockerEngine); err != nil {
 			return err
 		}
 		deps.Manager.SetDefaultEngine(dockerEngine)
 	}
 	if hasPodman {
-		podmanEngine = dcmd.NewEngine("podman")
+		podmanEngine = dcmd.NewInstrumentedEngine("podman", deps.Metrics)
 		if err = deps.Manager.AddEngine(ocservices.DockerEngine, podmanEngine); err != nil {
 			return err
 		}
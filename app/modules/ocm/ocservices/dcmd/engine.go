@@ -10,6 +10,8 @@ import (
 	"github.com/goatcms/goatcore/varutil"
 
 	"github.com/goatcms/goatcore/app/gio"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
 	"github.com/goatcms/goatcore/app/modules/ocm/ocservices"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
@@ -17,6 +19,7 @@ import (
 // Engine SandboxesEngine is a tool to menage sandboxes.
 type Engine struct {
 	appName string
+	metrics commservices.Metrics
 }
 
 // NewEngine create docekr engine instance
@@ -26,8 +29,31 @@ func NewEngine(appName string) ocservices.Engine {
 	}
 }
 
+// NewInstrumentedEngine create docker engine instance and record container runs metrics
+func NewInstrumentedEngine(appName string, metrics commservices.Metrics) ocservices.Engine {
+	return &Engine{
+		appName: appName,
+		metrics: metrics,
+	}
+}
+
 // Run container
 func (engine *Engine) Run(container ocservices.Container) (err error) {
+	if engine.metrics == nil {
+		return engine.run(container)
+	}
+	start := time.Now()
+	err = engine.run(container)
+	labels := commservices.MetricLabels{
+		"engine": engine.appName,
+		"status": metrics.Status(err),
+	}
+	engine.metrics.Counter("goat_containers_total", "Number of executed containers", labels).Inc()
+	engine.metrics.Histogram("goat_container_duration_seconds", "Containers execution time", labels, nil).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (engine *Engine) run(container ocservices.Container) (err error) {
 	var (
 		cio        = container.IO
 		cwdAbs     string
@@ -79,9 +105,10 @@ func (engine *Engine) Run(container ocservices.Container) (err error) {
 			return err
 		}
 		defer container.Scope.DoneTask()
-		container.Scope.On(app.KillEvent, func(interface{}) error {
+		listenerID := container.Scope.On(app.KillEvent, func(interface{}) error {
 			return killContainer(name)
 		})
+		defer container.Scope.Off(listenerID)
 	}
 	cmd.Stdin = gio.NewSafeReader(io.MultiReader(initReader, cio.In()))
 	cmd.Stdout = gio.NewSafeWriter(cio.Out())
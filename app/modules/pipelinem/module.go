@@ -15,6 +15,7 @@ import (
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/sandboxes/selfsb"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/sandboxes/sshsb"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/tasks"
+	"github.com/goatcms/goatcore/dependency"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -31,9 +32,13 @@ func (m *Module) RegisterDependencies(a app.App) error {
 	dp := a.DependencyProvider()
 	return goaterr.ToError(goaterr.AppendError(nil,
 		dp.AddDefaultFactory(pipservices.SandboxesManagerService, sandboxes.ManagerFactory),
+		dependency.DeclareType[pipservices.SandboxesManager](dp, pipservices.SandboxesManagerService),
 		dp.AddDefaultFactory(pipservices.NamespacesUnitService, namespaces.UnitFactory),
+		dependency.DeclareType[pipservices.NamespacesUnit](dp, pipservices.NamespacesUnitService),
 		dp.AddDefaultFactory(pipservices.RunnerService, runner.Factory),
+		dependency.DeclareType[pipservices.Runner](dp, pipservices.RunnerService),
 		dp.AddDefaultFactory(pipservices.TasksUnitService, tasks.UnitFactory),
+		dependency.DeclareType[pipservices.TasksUnit](dp, pipservices.TasksUnitService),
 		app.RegisterCommand(a, "pip:clear", pipc.Clear, pipcommands.PipClear),
 		app.RegisterCommand(a, "pip:run", pipc.Run, pipcommands.PipRun),
 		app.RegisterCommand(a, "pip:try", pipc.Try, pipcommands.PipTry),
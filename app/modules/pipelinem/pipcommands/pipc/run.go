@@ -2,27 +2,28 @@ package pipc
 
 import (
 	"strings"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
 	"github.com/goatcms/goatcore/varutil/goaterr"
-	"github.com/goatcms/goatcore/varutil/varg"
 )
 
 // Run run pip:run command
 func Run(a app.App, ctx app.IOContext) (err error) {
 	var (
 		deps struct {
-			Name        string `command:"?name"`
-			Description string `command:"?description"`
-			Body        string `command:"?body"`
-			RLock       string `command:"?rlock"`
-			RWLock      string `command:"?wlock"`
-			Wait        string `command:"?wait"`
-			Sandbox     string `command:"?sandbox"`
-			Silent      string `command:"?silent" ,argument:"?silent"`
+			Name        string        `command:"?name"`
+			Description string        `command:"?description"`
+			Body        string        `command:"?body"`
+			RLock       string        `command:"?rlock"`
+			RWLock      string        `command:"?wlock"`
+			Wait        string        `command:"?wait"`
+			Sandbox     string        `command:"?sandbox"`
+			Silent      bool          `command:"?silent,default=true"`
+			Timeout     time.Duration `command:"?timeout"`
 
 			Runner         pipservices.Runner         `dependency:"PipRunner"`
 			NamespacesUnit pipservices.NamespacesUnit `dependency:"PipNamespacesUnit"`
@@ -33,12 +34,11 @@ func Run(a app.App, ctx app.IOContext) (err error) {
 		lockMap       = commservices.LockMap{}
 		wait          []string
 		lockNamespace string
-		silent        bool
 	)
 	if err = goaterr.ToError(goaterr.AppendError(nil,
-		ctx.Scope().InjectTo(&deps),
 		a.DependencyProvider().InjectTo(&deps),
 		a.ArgsScope().InjectTo(&deps),
+		ctx.Scope().InjectTo(&deps),
 	)); err != nil {
 		return err
 	}
@@ -49,9 +49,6 @@ func Run(a app.App, ctx app.IOContext) (err error) {
 	if !namePattern.MatchString(deps.Name) {
 		return goaterr.Errorf("pip:run Name '%s' is incorrect", deps.Name)
 	}
-	if silent, err = varg.MatchBool("silent argument", deps.Silent, true); err != nil {
-		return err
-	}
 	deps.Body = strings.Trim(deps.Body, cutset)
 	if deps.Body == "" {
 		return goaterr.Errorf("pip:run Body is required")
@@ -80,7 +77,7 @@ func Run(a app.App, ctx app.IOContext) (err error) {
 		}
 	}
 	ctxIO := ctx.IO()
-	if silent {
+	if deps.Silent {
 		out = gio.NewNilOutput()
 		erro = out
 	} else {
@@ -101,5 +98,6 @@ func Run(a app.App, ctx app.IOContext) (err error) {
 		Sandbox:     deps.Sandbox,
 		Lock:        lockMap,
 		Wait:        wait,
+		Timeout:     deps.Timeout,
 	})
 }
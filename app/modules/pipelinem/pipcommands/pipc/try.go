@@ -10,7 +10,6 @@ import (
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/namespaces"
 	"github.com/goatcms/goatcore/varutil/goaterr"
-	"github.com/goatcms/goatcore/varutil/varg"
 )
 
 // Try run pip:try command
@@ -23,7 +22,7 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 			SuccessBody string `command:"?success"`
 			FailBody    string `command:"?fail"`
 			FinallyBody string `command:"?finally"`
-			Silent      string `command:"?silent" ,argument:"?silent"`
+			Silent      bool   `command:"?silent,default=true"`
 
 			Runner         pipservices.Runner         `dependency:"PipRunner"`
 			NamespacesUnit pipservices.NamespacesUnit `dependency:"PipNamespacesUnit"`
@@ -32,12 +31,11 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 		out           app.Output
 		erro          app.Output
 		scpNamespaces pipservices.Namespaces
-		silent        bool
 	)
 	if err = goaterr.ToError(goaterr.AppendError(nil,
-		ctx.Scope().InjectTo(&deps),
 		a.DependencyProvider().InjectTo(&deps),
 		a.ArgsScope().InjectTo(&deps),
+		ctx.Scope().InjectTo(&deps),
 	)); err != nil {
 		return err
 	}
@@ -48,9 +46,6 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 	if !namePattern.MatchString(deps.Name) {
 		return goaterr.Errorf("pip:try Name '%s' is incorrect", deps.Name)
 	}
-	if silent, err = varg.MatchBool("silent argument", deps.Silent, true); err != nil {
-		return err
-	}
 	deps.TryBody = strings.Trim(deps.TryBody, cutset)
 	if deps.TryBody == "" {
 		return goaterr.Errorf("pip:try Body is required")
@@ -65,7 +60,7 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 		Task: deps.Name,
 	})
 	ctxIO := ctx.IO()
-	if silent {
+	if deps.Silent {
 		out = gio.NewNilOutput()
 		erro = out
 	} else {
@@ -80,11 +75,13 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 	if err = parentScope.AddTasks(1); err != nil {
 		return err
 	}
-	// create independed scope for try body
+	// create independed scope for try body (the body data is committed to the parent scope on success)
+	separatedData := scope.NewTransactionDataScope(parentScope).(*scope.TransactionDataScope)
 	separatedScope := scope.NewScope(scope.Params{
-		DataScope:  parentScope,
+		DataScope:  separatedData,
 		EventScope: parentScope,
 		Injectors:  []app.Injector{parentScope},
+		Parent:     parentScope,
 	})
 	if err = deps.Runner.Run(pipservices.Pip{
 		Context: pipservices.PipContext{
@@ -101,13 +98,23 @@ func Try(a app.App, ctx app.IOContext) (err error) {
 		Lock:        nil,    // lock is unsupported
 		Wait:        nil,    // wait is unsupported
 	}); err != nil {
+		scope.Release(separatedScope)
 		parentScope.DoneTask()
 		return err
 	}
 	go func() {
 		var catchErr error
 		defer parentScope.DoneTask()
-		catchErr = separatedScope.Wait()
+		defer scope.Release(separatedScope)
+		if catchErr = separatedScope.Wait(); catchErr != nil {
+			err = separatedData.Rollback()
+		} else {
+			err = separatedData.Commit()
+		}
+		if err != nil {
+			parentScope.AppendError(err)
+			return
+		}
 		// run finally
 		if deps.FinallyBody != "" {
 			if err = deps.Runner.Run(pipservices.Pip{
@@ -1,6 +1,8 @@
 package pipservices
 
 import (
+	"time"
+
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/filesystem"
@@ -24,4 +26,6 @@ type Pip struct {
 	Sandbox     string
 	Lock        commservices.LockMap
 	Wait        []string
+	// Timeout limit the task execution time (zero means no limit)
+	Timeout time.Duration
 }
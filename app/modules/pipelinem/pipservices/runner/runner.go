@@ -2,20 +2,25 @@ package runner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
+	"github.com/goatcms/goatcore/app/scope"
 	"github.com/goatcms/goatcore/dependency"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
 // Deps is deps for runner
 type Deps struct {
+	App              app.App                      `dependency:"App"`
 	SandboxesManager pipservices.SandboxesManager `dependency:"PipSandboxesManager"`
 	TasksUnit        pipservices.TasksUnit        `dependency:"PipTasksUnit"`
 	SharedMutex      commservices.SharedMutex     `dependency:"CommonSharedMutex"`
+	Metrics          commservices.Metrics         `dependency:"?CommonMetrics"`
 }
 
 // Runner is piplines repository
@@ -65,18 +70,33 @@ func (runner *Runner) runGo(tasksManager pipservices.TasksManager, sandbox pipse
 		unlockHandler commservices.UnlockHandler
 		err           error
 		childCtx      app.IOContext
+		start         = time.Now()
 	)
 	defer task.Close()
-	childCtx = gio.NewChildIOContext(task.IOContext(), gio.ChildIOContextParams{})
-	defer childCtx.Close()
+	defer func() {
+		runner.observe(start, err)
+	}()
 	if err = runner.waitForTasks(task, tasksManager); err != nil {
-		childCtx.Scope().AppendError(err)
+		task.IOContext().Scope().AppendError(err)
 		return
 	}
 	task.SetStatus("wait for resources")
 	unlockHandler = runner.deps.SharedMutex.Lock(task.LockMap())
 	defer unlockHandler.Unlock()
 	task.SetStatus("execute")
+	// the timeout limits the execution only (waiting for tasks and resources is not counted)
+	childCtx = gio.NewChildIOContext(task.IOContext(), gio.ChildIOContextParams{
+		Scope: scope.ChildParams{
+			Name:    task.FullName(),
+			Timeout: task.Timeout(),
+		},
+	})
+	defer childCtx.Close()
+	if _, err = app.ScopeDependencyProvider(runner.deps.App, childCtx.Scope()); err != nil {
+		childCtx.Scope().AppendError(err)
+		task.SetStatus("fail")
+		return
+	}
 	if err = sandbox.Run(childCtx); err != nil {
 		childCtx.Scope().AppendError(err)
 		task.SetStatus("fail")
@@ -91,6 +111,18 @@ func (runner *Runner) runGo(tasksManager pipservices.TasksManager, sandbox pipse
 	task.SetStatus("success")
 }
 
+// observe record task metrics (if metrics service is defined)
+func (runner *Runner) observe(start time.Time, err error) {
+	if runner.deps.Metrics == nil {
+		return
+	}
+	labels := commservices.MetricLabels{
+		"status": metrics.Status(err),
+	}
+	runner.deps.Metrics.Counter("goat_tasks_total", "Number of executed pipeline tasks", labels).Inc()
+	runner.deps.Metrics.Histogram("goat_task_duration_seconds", "Pipeline tasks execution time", labels, nil).Observe(time.Since(start).Seconds())
+}
+
 // waitForTasks wait for all related task
 func (runner *Runner) waitForTasks(task pipservices.TaskWriter, tasksManager pipservices.TasksManager) (err error) {
 	var (
@@ -3,6 +3,7 @@ package runner
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/gio"
@@ -75,3 +76,70 @@ func TestRunner(t *testing.T) {
 		return
 	}
 }
+
+func TestRunnerTimeoutExcludeResourcesWait(t *testing.T) {
+	t.Parallel()
+	var (
+		err  error
+		mapp app.App
+		scp  = scope.NewScope(scope.Params{})
+		cwd  filesystem.Filespace
+	)
+	if mapp, err = newApp(); err != nil {
+		t.Error(err)
+		return
+	}
+	var deps struct {
+		Runner      pipservices.Runner       `dependency:"PipRunner"`
+		SharedMutex commservices.SharedMutex `dependency:"CommonSharedMutex"`
+	}
+	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
+		t.Error(err)
+		return
+	}
+	buffer := bufferio.NewBuffer()
+	if cwd, err = memfs.NewFilespace(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = app.RegisterCommand(mapp, "testCommand", func(a app.App, ctx app.IOContext) (err error) {
+		return ctx.IO().Out().Printf("output")
+	}, "description"); err != nil {
+		t.Error(err)
+		return
+	}
+	unlockHandler := deps.SharedMutex.Lock(commservices.LockMap{"resource": true})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		unlockHandler.Unlock()
+	}()
+	if err = deps.Runner.Run(pipservices.Pip{
+		Context: pipservices.PipContext{
+			In:    gio.NewInput(strings.NewReader("testCommand")),
+			Out:   bufferio.NewBufferOutput(buffer),
+			Err:   bufferio.NewBufferOutput(buffer),
+			Scope: scp,
+			CWD:   cwd,
+		},
+		Name: "name",
+		Namespaces: namespaces.NewNamespaces(pipservices.NamasepacesParams{
+			Task: "",
+			Lock: "",
+		}),
+		Sandbox: "self",
+		Lock:    commservices.LockMap{"resource": true},
+		Wait:    []string{},
+		Timeout: 50 * time.Millisecond,
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = scp.Wait(); err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(buffer.String(), "output") {
+		t.Errorf("expected output")
+		return
+	}
+}
@@ -1,6 +1,8 @@
 package pipservices
 
 import (
+	"time"
+
 	"github.com/goatcms/goatcore/app"
 	commservices "github.com/goatcms/goatcore/app/modules/commonm/commservices"
 )
@@ -27,6 +29,8 @@ type Task interface {
 	WaitList() []string
 	// WaitLockMapList return map described related resources to lock
 	LockMap() commservices.LockMap
+	// Timeout return task execution time limit (zero means no limit)
+	Timeout() time.Duration
 	// Errors return task errors (or nil)
 	Errors() []error
 }
@@ -65,4 +69,6 @@ type TasksUnit interface {
 	FromScope(scp app.Scope) (tasks TasksManager, err error)
 	BindScope(scp app.Scope, tasks TasksManager) (err error)
 	Clear(scp app.Scope) (err error)
+	// Managers return task managers bound to live scopes (it doesn't create a manager)
+	Managers() []TasksManager
 }
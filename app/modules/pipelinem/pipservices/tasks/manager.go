@@ -8,6 +8,7 @@ import (
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/gio/bufferio"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices/namespaces"
 	"github.com/goatcms/goatcore/app/scope"
@@ -96,7 +97,9 @@ func (manager *TaskManager) Create(pip pipservices.Pip) (result pipservices.Task
 	if _, ok = manager.tasks[taskname]; ok {
 		return nil, goaterr.Errorf("Task '%s' is already defined", taskname)
 	}
-	childScope = scope.NewChildScope(parentScope, scope.ChildParams{})
+	childScope = scope.NewChildScope(parentScope, scope.ChildParams{
+		Name: taskname,
+	})
 	if err = manager.deps.NamespacesUnit.Define(childScope, childNamespaces); err != nil {
 		childScope.Close()
 		return nil, err
@@ -105,6 +108,13 @@ func (manager *TaskManager) Create(pip pipservices.Pip) (result pipservices.Task
 		childScope.Close()
 		return nil, err
 	}
+	if err = commservices.DefineLogScopeFields(childScope, commservices.LogFields{
+		"task":      pip.Name,
+		"namespace": pip.Namespaces.Task(),
+	}); err != nil {
+		childScope.Close()
+		return nil, err
+	}
 	taskCtx = gio.NewIOContext(childScope, gio.NewIO(gio.IOParams{
 		In:  pip.Context.In,
 		Out: pip.Context.Out,
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"sync"
+	"time"
 
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/gio/bufferio"
@@ -134,6 +135,11 @@ func (task *Task) LockMap() commservices.LockMap {
 	return task.pip.Lock
 }
 
+// Timeout return task execution time limit (zero means no limit)
+func (task *Task) Timeout() time.Duration {
+	return task.pip.Timeout
+}
+
 // Status return taks status
 func (task *Task) Status() string {
 	return task.status
@@ -1,6 +1,8 @@
 package tasks
 
 import (
+	"sync"
+
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
 	"github.com/goatcms/goatcore/dependency"
@@ -11,9 +13,11 @@ type UnitDeps struct {
 	NamespacesUnit pipservices.NamespacesUnit `dependency:"PipNamespacesUnit"`
 }
 
-// Unit connect scope with tasks
+// Unit connect scope with tasks. It keeps task managers of live scopes.
 type Unit struct {
-	deps UnitDeps
+	deps       UnitDeps
+	managersMU sync.RWMutex
+	managers   []pipservices.TasksManager
 }
 
 // NewUnit create a Unit instance
@@ -50,15 +54,64 @@ func (unit *Unit) FromScope(scp app.Scope) (tasks pipservices.TasksManager, err
 	if err = locker.Set(scopeKey, manager); err != nil {
 		return nil, err
 	}
+	unit.register(scp, manager)
 	return manager, nil
 }
 
 // BindScope bind scope to task manager
 func (unit *Unit) BindScope(scp app.Scope, manager pipservices.TasksManager) (err error) {
-	return scp.Set(scopeKey, manager)
+	if err = scp.Set(scopeKey, manager); err != nil {
+		return err
+	}
+	unit.register(scp, manager)
+	return nil
 }
 
 // Clear remove pipelines scope data
 func (unit *Unit) Clear(scp app.Scope) (err error) {
+	var ins interface{}
+	if ins, err = scp.Get(scopeKey); err != nil {
+		return err
+	}
+	if manager, ok := ins.(pipservices.TasksManager); ok {
+		unit.unregister(manager)
+	}
 	return scp.Set(scopeKey, nil)
 }
+
+// Managers return task managers bound to live scopes
+func (unit *Unit) Managers() (managers []pipservices.TasksManager) {
+	unit.managersMU.RLock()
+	defer unit.managersMU.RUnlock()
+	return append(managers, unit.managers...)
+}
+
+// register add the manager to live managers until the scope is closed
+func (unit *Unit) register(scp app.Scope, manager pipservices.TasksManager) {
+	unit.managersMU.Lock()
+	for _, current := range unit.managers {
+		if current == manager {
+			unit.managersMU.Unlock()
+			return
+		}
+	}
+	unit.managers = append(unit.managers, manager)
+	unit.managersMU.Unlock()
+	closeCallback := func(interface{}) error {
+		unit.unregister(manager)
+		return nil
+	}
+	scp.On(app.CommitEvent, closeCallback)
+	scp.On(app.RollbackEvent, closeCallback)
+}
+
+func (unit *Unit) unregister(manager pipservices.TasksManager) {
+	unit.managersMU.Lock()
+	defer unit.managersMU.Unlock()
+	for i, current := range unit.managers {
+		if current == manager {
+			unit.managers = append(unit.managers[:i:i], unit.managers[i+1:]...)
+			return
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/modules/pipelinem/pipservices"
+	"github.com/goatcms/goatcore/app/scope"
 )
 
 func TestManagertory(t *testing.T) {
@@ -26,3 +27,29 @@ func TestManagertory(t *testing.T) {
 		return
 	}
 }
+
+func TestUnitManagers(t *testing.T) {
+	t.Parallel()
+	var (
+		err     error
+		manager pipservices.TasksManager
+		unit    = NewUnit(UnitDeps{})
+		scp     = scope.NewScope(scope.Params{})
+	)
+	if len(unit.Managers()) != 0 {
+		t.Errorf("expected no managers")
+		return
+	}
+	if manager, err = unit.FromScope(scp); err != nil {
+		t.Error(err)
+		return
+	}
+	if managers := unit.Managers(); len(managers) != 1 || managers[0] != manager {
+		t.Errorf("expected the scope manager and take %v", managers)
+		return
+	}
+	scp.Close()
+	if len(unit.Managers()) != 0 {
+		t.Errorf("manager of closed scope should be removed")
+	}
+}
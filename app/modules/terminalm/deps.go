@@ -0,0 +1,34 @@
+package terminalm
+
+import (
+	"strings"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// DepsCommand run deps command. It show application dependencies graph as a tree (default) or in Graphviz DOT format (--format=dot).
+func DepsCommand(a app.App, ctx app.IOContext) (err error) {
+	var (
+		deps struct {
+			Format string `command:"?format"`
+		}
+		graph = a.DependencyProvider().Graph()
+		out   = ctx.IO().Out()
+	)
+	if err = ctx.Scope().InjectTo(&deps); err != nil {
+		return err
+	}
+	switch strings.ToLower(deps.Format) {
+	case "", treeFormat:
+		out.Printf("\nDependencies:\n")
+		if err = graph.WriteTree(out); err != nil {
+			return err
+		}
+		out.Printf("\n")
+		return nil
+	case dotFormat:
+		return graph.WriteDOT(out)
+	}
+	return goaterr.Errorf("deps: unknown '%s' format (use %s or %s)", deps.Format, treeFormat, dotFormat)
+}
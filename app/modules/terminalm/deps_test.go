@@ -0,0 +1,57 @@
+package terminalm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/app/bootstrap"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/modules"
+)
+
+func TestDepsCommand(t *testing.T) {
+	var (
+		err  error
+		mapp *mockupapp.App
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(NewModule()); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	var deps struct {
+		Terminal modules.Terminal `dependency:"TerminalService"`
+	}
+	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = deps.Terminal.RunString(mapp.IOContext(), "deps"); err != nil {
+		t.Error(err)
+		return
+	}
+	out := mapp.OutputBuffer().String()
+	if !strings.Contains(out, "TerminalService [default factory, singleton]") {
+		t.Errorf("expected TerminalService in dependencies tree and take: %s", out)
+	}
+	if err = deps.Terminal.RunString(mapp.IOContext(), "deps --format=dot"); err != nil {
+		t.Error(err)
+		return
+	}
+	out = mapp.OutputBuffer().String()
+	if !strings.Contains(out, "digraph dependencies {") || !strings.Contains(out, `"TerminalService" [label="TerminalService\n[default factory, singleton]"];`) {
+		t.Errorf("expected DOT graph and take: %s", out)
+	}
+	if err = deps.Terminal.RunString(mapp.IOContext(), "deps --format=xml"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
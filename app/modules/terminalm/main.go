@@ -4,4 +4,7 @@ const (
 	commandPrefix  = "help.command."
 	healthPrefix   = "health."
 	argumentPrefix = "help.argument."
+
+	treeFormat = "tree"
+	dotFormat  = "dot"
 )
@@ -7,6 +7,7 @@ import (
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/modules"
 	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/dependency"
 )
 
 // Module is command unit
@@ -21,8 +22,11 @@ func NewModule() app.Module {
 func (m *Module) RegisterDependencies(a app.App) error {
 	dp := a.DependencyProvider()
 	dp.AddDefaultFactory(modules.TerminalService, IOTerminalFactory)
+	dependency.DeclareType[modules.Terminal](dp, modules.TerminalService)
 	app.RegisterCommand(a, "health", HealthComamnd, "chack and show application health")
 	app.RegisterCommand(a, "help", HelpComamnd, "Show help")
+	app.RegisterCommand(a, "deps", DepsCommand, "show dependencies graph (--format=tree|dot)")
+	app.RegisterCommand(a, "scope:tree", ScopeTreeCommand, "show live scopes tree (pending tasks, errors and data keys)")
 	return nil
 }
 
@@ -0,0 +1,17 @@
+package terminalm
+
+import (
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/scope"
+)
+
+// ScopeTreeCommand run scope:tree command. It show live scopes as a tree with pending tasks, errors and data keys.
+func ScopeTreeCommand(a app.App, ctx app.IOContext) (err error) {
+	out := ctx.IO().Out()
+	out.Printf("\nScopes:\n")
+	if err = scope.LiveTree().WriteTree(out); err != nil {
+		return err
+	}
+	out.Printf("\n")
+	return nil
+}
@@ -0,0 +1,56 @@
+package terminalm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/app/bootstrap"
+	"github.com/goatcms/goatcore/app/mockupapp"
+	"github.com/goatcms/goatcore/app/modules"
+	"github.com/goatcms/goatcore/app/scope"
+)
+
+func TestScopeTreeCommand(t *testing.T) {
+	var (
+		err  error
+		mapp *mockupapp.App
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(NewModule()); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	var deps struct {
+		Terminal modules.Terminal `dependency:"TerminalService"`
+	}
+	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
+		t.Error(err)
+		return
+	}
+	taskScope := scope.NewScope(scope.Params{
+		Name: "scope-tree-test",
+	})
+	defer taskScope.Close()
+	if err = taskScope.AddTasks(1); err != nil {
+		t.Error(err)
+		return
+	}
+	defer taskScope.DoneTask()
+	if err = deps.Terminal.RunString(mapp.IOContext(), "scope:tree"); err != nil {
+		t.Error(err)
+		return
+	}
+	out := mapp.OutputBuffer().String()
+	if !strings.Contains(out, "Scopes:") || !strings.Contains(out, "scope-tree-test [scope, tasks: 1]") {
+		t.Errorf("expected scope-tree-test scope in scopes tree and take: %s", out)
+	}
+}
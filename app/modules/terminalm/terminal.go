@@ -3,10 +3,13 @@ package terminalm
 import (
 	"io"
 	"strings"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/modules"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices"
+	"github.com/goatcms/goatcore/app/modules/commonm/commservices/metrics"
 	"github.com/goatcms/goatcore/app/scope"
 	"github.com/goatcms/goatcore/app/scope/argscope"
 	"github.com/goatcms/goatcore/dependency"
@@ -17,7 +20,8 @@ import (
 // IOTerminal is user communication interface
 type IOTerminal struct {
 	deps struct {
-		App app.App `dependency:"App"`
+		App     app.App              `dependency:"App"`
+		Metrics commservices.Metrics `dependency:"?CommonMetrics"`
 	}
 }
 
@@ -75,7 +79,8 @@ func (terminal *IOTerminal) RunCommandFromReader(ctx app.IOContext, reader io.Re
 	return eof, terminal.RunCommand(ctx, args)
 }
 
-// RunCommand execute single command
+// RunCommand execute single command. The command writes to its scope data are committed
+// to the context scope when the command succeeds (and rolled back on error or kill).
 func (terminal *IOTerminal) RunCommand(ctx app.IOContext, args []string) (err error) {
 	var (
 		commandName    string
@@ -103,15 +108,41 @@ func (terminal *IOTerminal) RunCommand(ctx app.IOContext, args []string) (err er
 		return err
 	}
 	baseScope := ctx.Scope()
+	commandData := scope.NewTransactionDataScope(baseScope).(*scope.TransactionDataScope)
+	defer func() {
+		err = commitCommandData(commandData, baseScope, err)
+	}()
 	injectableScope := scope.NewScope(scope.Params{
-		DataScope:  baseScope,
+		DataScope:  commandData,
 		EventScope: baseScope,
 		SyncScope:  baseScope,
 		Injectors: []app.Injector{
 			argsData.Injector("command"),
 		},
 	})
+	if _, err = app.ScopeDependencyProvider(terminal.deps.App, injectableScope); err != nil {
+		return err
+	}
 	commandContext = gio.NewIOContext(injectableScope, ctx.IO())
 	// run
-	return cb(terminal.deps.App, commandContext)
+	if terminal.deps.Metrics == nil {
+		return cb(terminal.deps.App, commandContext)
+	}
+	start := time.Now()
+	err = cb(terminal.deps.App, commandContext)
+	labels := commservices.MetricLabels{
+		"command": commandName,
+		"status":  metrics.Status(err),
+	}
+	terminal.deps.Metrics.Counter("goat_commands_total", "Number of executed commands", labels).Inc()
+	terminal.deps.Metrics.Histogram("goat_command_duration_seconds", "Commands execution time", labels, nil).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// commitCommandData commit the command data (or rollback it if the command failed or was killed)
+func commitCommandData(data *scope.TransactionDataScope, scp app.SyncScope, err error) error {
+	if err != nil || scp.IsKilled() {
+		return goaterr.ToError(goaterr.AppendError(nil, err, data.Rollback()))
+	}
+	return data.Commit()
 }
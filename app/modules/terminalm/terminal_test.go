@@ -1,13 +1,17 @@
 package terminalm
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/bootstrap"
+	"github.com/goatcms/goatcore/app/gio"
 	"github.com/goatcms/goatcore/app/mockupapp"
 	"github.com/goatcms/goatcore/app/modules"
+	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/dependency"
 )
 
 func TestRunLoop(t *testing.T) {
@@ -51,3 +55,106 @@ func TestRunLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestRunCommandWithScopedProvider(t *testing.T) {
+	var (
+		err      error
+		mapp     *mockupapp.App
+		provider interface{}
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(NewModule()); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = app.RegisterCommand(mapp, "scoped", func(a app.App, ctx app.IOContext) (err error) {
+		provider, err = ctx.Scope().Get(app.ScopedProviderKey)
+		return err
+	}, "description"); err != nil {
+		t.Error(err)
+		return
+	}
+	var deps struct {
+		Terminal modules.Terminal `dependency:"TerminalService"`
+	}
+	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = deps.Terminal.RunCommand(mapp.IOContext(), []string{"scoped"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := provider.(dependency.ScopedProvider); !ok {
+		t.Errorf("expected a scoped provider in the command scope and take %T", provider)
+	}
+}
+
+func TestRunCommandDataTransaction(t *testing.T) {
+	var (
+		err   error
+		mapp  *mockupapp.App
+		value interface{}
+	)
+	t.Parallel()
+	if mapp, err = mockupapp.NewApp(mockupapp.MockupOptions{}); err != nil {
+		t.Error(err)
+		return
+	}
+	bootstrap := bootstrap.NewBootstrap(mapp)
+	if err = bootstrap.Register(NewModule()); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = bootstrap.Init(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = app.RegisterCommand(mapp, "succeed", func(a app.App, ctx app.IOContext) (err error) {
+		return ctx.Scope().Set("succeed.key", "value")
+	}, "description"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = app.RegisterCommand(mapp, "fail", func(a app.App, ctx app.IOContext) (err error) {
+		if err = ctx.Scope().Set("fail.key", "value"); err != nil {
+			return err
+		}
+		return fmt.Errorf("command error")
+	}, "description"); err != nil {
+		t.Error(err)
+		return
+	}
+	var deps struct {
+		Terminal modules.Terminal `dependency:"TerminalService"`
+	}
+	if err = mapp.DependencyProvider().InjectTo(&deps); err != nil {
+		t.Error(err)
+		return
+	}
+	ctx := gio.NewIOContext(scope.NewScope(scope.Params{}), mapp.IOContext().IO())
+	defer ctx.Close()
+	if err = deps.Terminal.RunCommand(ctx, []string{"succeed"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, err = ctx.Scope().Get("succeed.key"); err != nil || value != "value" {
+		t.Errorf("expected committed command data and take %v (%v)", value, err)
+	}
+	if err = deps.Terminal.RunCommand(ctx, []string{"fail"}); err == nil {
+		t.Errorf("expected command error")
+		return
+	}
+	if value, _ = ctx.Scope().Get("fail.key"); value != nil {
+		t.Errorf("failed command data should be rolled back and take %v", value)
+	}
+}
@@ -12,18 +12,33 @@ type DataScope interface {
 	Get(string) (interface{}, error)
 	Keys() ([]string, error)
 	LockData() (transaction DataScopeLocker)
+	// Watch connect a callback to changes of a key. A key ending with '*' is a prefix
+	// (for example "pipeline.*"). The callback is run after the change commits.
+	Watch(string, DataWatchCallback) WatcherID
+	// Unwatch disconnect a watch callback
+	Unwatch(WatcherID)
 }
 
-// DataScopeLocker provide data scope commitable interface
+// DataScopeLocker provide data scope commitable interface. Writes are buffered
+// and applied on Commit or discarded on Rollback.
 type DataScopeLocker interface {
 	DataScope
 	Commit() (err error)
+	Rollback() (err error)
 }
 
 // EventScope provide event interface
 type EventScope interface {
+	// Trigger run event callbacks synchronously (by priority). It stops at the first error.
 	Trigger(int, interface{}) error
-	On(int, EventCallback)
+	// On connect a callback to the event with default (zero) priority
+	On(int, EventCallback) ListenerID
+	// OnPriority connect a callback to the event. Callbacks with higher priority are run first.
+	OnPriority(int, int, EventCallback) ListenerID
+	// Off disconnect a callback
+	Off(ListenerID)
+	// Listeners return event callbacks in the trigger order
+	Listeners(int) []EventCallback
 }
 
 // ErrorScope provide error interface
@@ -3,8 +3,10 @@ package scope
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/injector"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -17,6 +19,10 @@ type ChildScope struct {
 	errorsMU  sync.Mutex
 	waitGroup sync.WaitGroup
 	injectors []app.Injector
+	limit     *scopeLimit
+	id        uint64
+	tasks     int64
+	onKill    app.ListenerID
 }
 
 // NewChildScope create new instance of scope
@@ -28,16 +34,31 @@ func NewChildScope(parent app.Scope, params ChildParams) app.Scope {
 	if params.EventScope == nil {
 		params.EventScope = NewChildEventScope(parent)
 	}
-	return &ChildScope{
+	cs := &ChildScope{
 		parent:     parent,
 		DataScope:  params.DataScope,
 		EventScope: params.EventScope,
 		injectors:  params.Injectors,
+		limit:      newScopeLimit(parent.Context(), params.Name, params.Timeout, params.Deadline),
 	}
+	if cs.limit != nil {
+		cs.limit.watch(cs.AppendError)
+	}
+	cs.id = register(cs, parent, params.Name, ChildKind)
+	if local, ok := params.EventScope.(*ChildEventScope); ok {
+		// parent KillEvent is passed to the child scope callbacks
+		cs.onKill = parent.On(app.KillEvent, func(data interface{}) error {
+			return local.TriggerLocal(app.KillEvent, data)
+		})
+	}
+	return cs
 }
 
-// Context return shared context
+// Context return shared context (or own context if the scope has a deadline)
 func (cs *ChildScope) Context() context.Context {
+	if cs.limit != nil {
+		return cs.limit.ctx
+	}
 	return cs.parent.Context()
 }
 
@@ -46,69 +67,119 @@ func (cs *ChildScope) Kill() {
 	cs.parent.Kill()
 }
 
-// IsKilled return true if shared context is killed/ended
+// IsKilled return true if shared context is killed/ended (or the scope deadline is exceeded)
 func (cs *ChildScope) IsKilled() bool {
+	if cs.limit != nil && cs.limit.ctx.Err() != nil {
+		return true
+	}
 	return cs.parent.IsKilled()
 }
 
 // Wait for end of all tasks in child scope
 func (cs *ChildScope) Wait() (err error) {
 	cs.waitGroup.Wait()
-	return goaterr.ToError(cs.errors)
+	if cs.limit != nil {
+		cs.limit.check(cs.AppendError)
+	}
+	return goaterr.ToError(cs.Errors())
 }
 
 // AddTasks tasks to child scope
 func (cs *ChildScope) AddTasks(delta int) (err error) {
+	atomic.AddInt64(&cs.tasks, int64(delta))
 	cs.waitGroup.Add(delta)
 	return nil
 }
 
 // DoneTask done one child scope task
 func (cs *ChildScope) DoneTask() {
+	atomic.AddInt64(&cs.tasks, -1)
 	cs.waitGroup.Done()
 }
 
+// PendingTasks return number of added and not done tasks
+func (cs *ChildScope) PendingTasks() int64 {
+	return atomic.LoadInt64(&cs.tasks)
+}
+
+// ScopeID return the scope ID in the live scopes registry
+func (cs *ChildScope) ScopeID() uint64 {
+	return cs.id
+}
+
 // Errors return child scope errors
 func (cs *ChildScope) Errors() []error {
-	return cs.errors
+	cs.errorsMU.Lock()
+	defer cs.errorsMU.Unlock()
+	if len(cs.errors) == 0 {
+		return nil
+	}
+	return append([]error{}, cs.errors...)
 }
 
 // ToError return error if child scope contains any error
 func (cs *ChildScope) ToError() error {
-	return goaterr.ToError(cs.errors)
+	return goaterr.ToError(cs.Errors())
 }
 
 // AppendError add error to child and parent scope
 func (cs *ChildScope) AppendError(err error) {
 	cs.errorsMU.Lock()
-	defer cs.errorsMU.Unlock()
+	cs.errors = append(cs.errors, err)
+	cs.errorsMU.Unlock()
 	cs.Kill()
 	cs.parent.AppendError(err)
-	cs.errors = append(cs.errors, err)
 }
 
 // AppendErrors add errors to child and parent scope
 func (cs *ChildScope) AppendErrors(errs ...error) {
 	cs.errorsMU.Lock()
-	defer cs.errorsMU.Unlock()
+	cs.errors = append(cs.errors, errs...)
+	cs.errorsMU.Unlock()
 	cs.Kill()
 	cs.parent.AppendErrors(errs...)
-	cs.errors = append(cs.errors, errs...)
 }
 
-// InjectTo insert data to object
+// InjectTo insert data to object. Default values are set for keys not found in the scope and its parents.
 func (cs *ChildScope) InjectTo(obj interface{}) (err error) {
+	return injector.InjectTo(cs, obj)
+}
+
+// InjectChain insert data to object as a part of an injection chain (see injector.ChainInjector)
+func (cs *ChildScope) InjectChain(obj interface{}, found injector.Found) (err error) {
 	for _, scpInjector := range cs.injectors {
-		if err = scpInjector.InjectTo(obj); err != nil {
+		if err = injector.InjectChain(scpInjector, obj, found); err != nil {
 			return err
 		}
 	}
-	return cs.parent.InjectTo(obj)
+	return injector.InjectChain(cs.parent, obj, found)
 }
 
-// Close child scope
+// Close child scope. It commits (or rollbacks on error) a transaction data scope
+// and triggers CloseEvent for child scope callbacks only.
 func (cs *ChildScope) Close() (err error) {
 	err = cs.Wait()
+	if cs.limit != nil {
+		cs.limit.cancel()
+	}
+	if tx, ok := cs.DataScope.(dataTransaction); ok {
+		if err != nil {
+			err = goaterr.ToError(goaterr.AppendError(nil, err, tx.Rollback()))
+		} else {
+			err = tx.Commit()
+		}
+	}
+	if cs.onKill != 0 {
+		cs.parent.Off(cs.onKill)
+	}
+	unregister(cs.id)
+	if data, ok := cs.DataScope.(*DataChildScope); ok {
+		// the child data scope watchers are disconnected from the parent data scope
+		data.unwatchAll()
+	}
+	if local, ok := cs.EventScope.(*ChildEventScope); ok {
+		err = goaterr.ToError(goaterr.AppendError(nil, err, local.TriggerLocal(app.CloseEvent, cs)))
+	}
 	cs.parent.DoneTask()
 	return err
 }
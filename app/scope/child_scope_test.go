@@ -155,3 +155,74 @@ func TestChildScopeInjector(t *testing.T) {
 		t.Errorf("expected result.Value equals to 'value'")
 	}
 }
+
+func TestChildScopeCloseTriggerLocalCloseEvent(t *testing.T) {
+	var (
+		parentScope  app.Scope
+		childScope   app.Scope
+		parentCalled bool
+		childCalled  bool
+	)
+	t.Parallel()
+	parentScope = NewScope(Params{})
+	parentScope.On(app.CloseEvent, func(interface{}) error {
+		parentCalled = true
+		return nil
+	})
+	childScope = NewChildScope(parentScope, ChildParams{})
+	childScope.On(app.CloseEvent, func(interface{}) error {
+		childCalled = true
+		return nil
+	})
+	if err := childScope.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+	if !childCalled {
+		t.Errorf("child scope close callback should be called")
+	}
+	if parentCalled {
+		t.Errorf("parent scope close callback shouldn't be called by child scope")
+	}
+}
+
+func TestChildScopeConcurrentErrors(t *testing.T) {
+	var (
+		parentScope app.Scope
+		childScope  app.Scope
+		wg          sync.WaitGroup
+	)
+	t.Parallel()
+	parentScope = NewScope(Params{})
+	childScope = NewChildScope(parentScope, ChildParams{
+		EventScope: parentScope,
+		DataScope:  parentScope,
+	})
+	defer childScope.Close()
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			childScope.AppendError(fmt.Errorf("error %d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			errs := childScope.Errors()
+			for i := range errs {
+				errs[i] = nil
+			}
+		}()
+	}
+	wg.Wait()
+	errs := childScope.Errors()
+	if len(errs) != 10 {
+		t.Errorf("expected 10 errors and take %d", len(errs))
+		return
+	}
+	for _, err := range errs {
+		if err == nil {
+			t.Errorf("Errors should return a copy of the errors list")
+			return
+		}
+	}
+}
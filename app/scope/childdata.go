@@ -9,24 +9,40 @@ import (
 
 // DataChildScope represent child data scope. It contains all parent data and its own.
 type DataChildScope struct {
-	parent app.DataScope
-	data   map[string]interface{}
-	mu     sync.RWMutex
+	parent   app.DataScope
+	data     map[string]interface{}
+	mu       sync.RWMutex
+	watchers dataWatchers
+	// own contains keys set in the child scope (parent changes of the keys are not watched).
+	// It is guarded by the ownMU (not by the mu) so parent watch callbacks never wait for the data lock.
+	own   map[string]bool
+	ownMU sync.RWMutex
 }
 
 // NewChildDataScope create new instance of child data scope
 func NewChildDataScope(parent app.DataScope, data map[string]interface{}) app.DataScope {
+	own := make(map[string]bool, len(data))
+	for key := range data {
+		own[key] = true
+	}
 	return app.DataScope(&DataChildScope{
 		parent: parent,
 		data:   data,
+		own:    own,
 	})
 }
 
 // Set new scope value
 func (scp *DataChildScope) Set(key string, v interface{}) error {
 	scp.mu.Lock()
-	defer scp.mu.Unlock()
+	old, ok := scp.data[key]
 	scp.data[key] = v
+	scp.markOwn(key)
+	scp.mu.Unlock()
+	if !ok {
+		old, _ = scp.parent.Get(key)
+	}
+	scp.watchers.notify(dataChange{key, old, v})
 	return nil
 }
 
@@ -65,5 +81,48 @@ func (scp *DataChildScope) Injector(tagname string) app.Injector {
 // LockData return new data locker
 func (scp *DataChildScope) LockData() (locker app.DataScopeLocker) {
 	scp.mu.Lock()
-	return newDataLocker(scp.data, scp.mu.Unlock, scp.parent)
+	dataLocker := newDataLocker(scp.data, scp.mu.Unlock, scp.parent, scp)
+	dataLocker.watchers = &scp.watchers
+	dataLocker.onCommit = scp.markOwn
+	return dataLocker
+}
+
+// markOwn mark keys set in the child scope
+func (scp *DataChildScope) markOwn(keys ...string) {
+	scp.ownMU.Lock()
+	defer scp.ownMU.Unlock()
+	for _, key := range keys {
+		scp.own[key] = true
+	}
+}
+
+// isOwn return true if the key is set in the child scope
+func (scp *DataChildScope) isOwn(key string) bool {
+	scp.ownMU.RLock()
+	defer scp.ownMU.RUnlock()
+	return scp.own[key]
+}
+
+// Watch connect a callback to changes of a key (or a prefix ending with '*').
+// The callback is notified about parent data changes too (except keys set in the child scope).
+func (scp *DataChildScope) Watch(pattern string, callback app.DataWatchCallback) app.WatcherID {
+	parentID := scp.parent.Watch(pattern, func(key string, oldValue, newValue interface{}) {
+		if scp.isOwn(key) {
+			return
+		}
+		callback(key, oldValue, newValue)
+	})
+	return scp.watchers.add(pattern, callback, func() {
+		scp.parent.Unwatch(parentID)
+	})
+}
+
+// Unwatch disconnect a watch callback
+func (scp *DataChildScope) Unwatch(id app.WatcherID) {
+	scp.watchers.remove(id)
+}
+
+// unwatchAll disconnect all watch callbacks (from the parent data scope too)
+func (scp *DataChildScope) unwatchAll() {
+	scp.watchers.clear()
 }
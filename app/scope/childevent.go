@@ -9,15 +9,16 @@ import (
 // ChildEventScope is event scope interface
 type ChildEventScope struct {
 	parent    app.EventScope
-	callbacks map[int][]app.EventCallback
+	listeners map[int][]eventListener
 	mu        sync.RWMutex
+	killed    bool
 }
 
 // NewChildEventScope create new instance of event scope
 func NewChildEventScope(parent app.EventScope) app.EventScope {
 	return app.EventScope(&ChildEventScope{
 		parent:    parent,
-		callbacks: make(map[int][]app.EventCallback),
+		listeners: make(map[int][]eventListener),
 	})
 }
 
@@ -26,24 +27,53 @@ func (es *ChildEventScope) Trigger(eID int, data interface{}) (err error) {
 	if err = es.parent.Trigger(eID, data); err != nil {
 		return err
 	}
-	es.mu.RLock()
-	defer es.mu.RUnlock()
-	for _, onFunc := range es.callbacks[eID] {
-		if err := onFunc(data); err != nil {
-			return err
+	return es.TriggerLocal(eID, data)
+}
+
+// TriggerLocal run functions connected to event in the child scope only (parent callbacks are skipped).
+// KillEvent functions are run once (the event can come from the parent and from the child scope).
+func (es *ChildEventScope) TriggerLocal(eID int, data interface{}) (err error) {
+	es.mu.Lock()
+	if eID == app.KillEvent {
+		if es.killed {
+			es.mu.Unlock()
+			return nil
 		}
+		es.killed = true
 	}
-	return nil
+	localCallbacks := callbacks(es.listeners[eID])
+	es.mu.Unlock()
+	return triggerCallbacks(localCallbacks, data)
 }
 
 // On connect a function to event
-func (es *ChildEventScope) On(eID int, callback app.EventCallback) {
+func (es *ChildEventScope) On(eID int, callback app.EventCallback) app.ListenerID {
+	return es.OnPriority(eID, 0, callback)
+}
+
+// OnPriority connect a function to event. Functions with higher priority are run first
+// (priorities are compared in the child scope only, parent functions are run before).
+func (es *ChildEventScope) OnPriority(eID int, priority int, callback app.EventCallback) app.ListenerID {
 	es.mu.Lock()
 	defer es.mu.Unlock()
-	callbacks, ok := es.callbacks[eID]
-	if !ok {
-		es.callbacks[eID] = []app.EventCallback{callback}
-		return
+	listener := newEventListener(priority, callback)
+	es.listeners[eID] = insertListener(es.listeners[eID], listener)
+	return listener.id
+}
+
+// Off disconnect a function (from the child or the parent scope)
+func (es *ChildEventScope) Off(id app.ListenerID) {
+	es.mu.Lock()
+	removed := removeListener(es.listeners, id)
+	es.mu.Unlock()
+	if !removed {
+		es.parent.Off(id)
 	}
-	es.callbacks[eID] = append(callbacks, callback)
+}
+
+// Listeners return parent and child functions connected to event
+func (es *ChildEventScope) Listeners(eID int) []app.EventCallback {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return append(es.parent.Listeners(eID), callbacks(es.listeners[eID])...)
 }
@@ -9,8 +9,9 @@ import (
 
 // DataScope represent scope data
 type DataScope struct {
-	Data map[string]interface{}
-	mu   sync.RWMutex
+	Data     map[string]interface{}
+	mu       sync.RWMutex
+	watchers dataWatchers
 }
 
 // NewDataScope create new instance of data scope
@@ -23,8 +24,10 @@ func NewDataScope(data map[string]interface{}) app.DataScope {
 // Set new scope value
 func (ds *DataScope) Set(key string, v interface{}) error {
 	ds.mu.Lock()
-	defer ds.mu.Unlock()
+	old := ds.Data[key]
 	ds.Data[key] = v
+	ds.mu.Unlock()
+	ds.watchers.notify(dataChange{key, old, v})
 	return nil
 }
 
@@ -56,5 +59,17 @@ func (ds *DataScope) Injector(tagname string) app.Injector {
 // LockData return new data locker
 func (ds *DataScope) LockData() (locker app.DataScopeLocker) {
 	ds.mu.Lock()
-	return newDataLocker(ds.Data, ds.mu.Unlock, nil)
+	dataLocker := newDataLocker(ds.Data, ds.mu.Unlock, nil, ds)
+	dataLocker.watchers = &ds.watchers
+	return dataLocker
+}
+
+// Watch connect a callback to changes of a key (or a prefix ending with '*')
+func (ds *DataScope) Watch(pattern string, callback app.DataWatchCallback) app.WatcherID {
+	return ds.watchers.add(pattern, callback, nil)
+}
+
+// Unwatch disconnect a watch callback
+func (ds *DataScope) Unwatch(id app.WatcherID) {
+	ds.watchers.remove(id)
 }
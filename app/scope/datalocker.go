@@ -5,39 +5,56 @@ import (
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/injector"
+	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
 type dataLockerUnlocker func()
 
-// DataLocker represent scope data
+// DataLocker represent a data scope transaction. It buffers writes and
+// applies them on Commit (or discards them on Rollback).
 type DataLocker struct {
-	data     map[string]interface{}
-	mu       sync.RWMutex
-	unlockCB dataLockerUnlocker
-	parent   app.DataScope
+	data       map[string]interface{}
+	changes    map[string]interface{}
+	owner      *DataLocker
+	mu         sync.RWMutex
+	unlockCB   dataLockerUnlocker
+	parent     app.DataScope
+	watchScope app.DataScope
+	watchers   *dataWatchers
+	onCommit   func(keys ...string)
+	closed     bool
 }
 
-// Commit unlock parent scope and close locker
-func newDataLocker(data map[string]interface{}, unlockCB dataLockerUnlocker, parent app.DataScope) (locker app.DataScopeLocker) {
+// newDataLocker create a locker for the data map. Changes are written to the data map on commit.
+// The watchScope is the locked data scope (Watch and Unwatch are delegated to it).
+func newDataLocker(data map[string]interface{}, unlockCB dataLockerUnlocker, parent, watchScope app.DataScope) *DataLocker {
 	return &DataLocker{
-		parent:   parent,
-		data:     data,
-		unlockCB: unlockCB,
+		parent:     parent,
+		watchScope: watchScope,
+		data:       data,
+		changes:    map[string]interface{}{},
+		unlockCB:   unlockCB,
 	}
 }
 
-// Set new scope value
+// Set new scope value. The value is visible outside the locker after commit.
 func (locker *DataLocker) Set(key string, v interface{}) error {
 	locker.mu.Lock()
 	defer locker.mu.Unlock()
-	locker.data[key] = v
+	if locker.closed {
+		return goaterr.Errorf("DataLocker.Set: locker is closed")
+	}
+	locker.changes[key] = v
 	return nil
 }
 
 // Get get value from context
 func (locker *DataLocker) Get(key string) (value interface{}, err error) {
 	var ok bool
-	if value, ok = locker.get(key); ok {
+	locker.mu.RLock()
+	value, ok = locker.lookup(key)
+	locker.mu.RUnlock()
+	if ok {
 		return value, nil
 	}
 	if locker.parent != nil {
@@ -46,40 +63,122 @@ func (locker *DataLocker) Get(key string) (value interface{}, err error) {
 	return nil, nil
 }
 
-func (locker *DataLocker) get(key string) (value interface{}, ok bool) {
-	locker.mu.RLock()
-	defer locker.mu.RUnlock()
-	value, ok = locker.data[key]
-	return value, ok
+// lookup find a value in changes and locked data. The owner locker is locked by the locker
+// so it is read without synchronization.
+func (locker *DataLocker) lookup(key string) (value interface{}, ok bool) {
+	if value, ok = locker.changes[key]; ok {
+		return value, true
+	}
+	if value, ok = locker.data[key]; ok {
+		return value, true
+	}
+	if locker.owner != nil {
+		return locker.owner.lookup(key)
+	}
+	return nil, false
 }
 
 // Keys get map data
 func (locker *DataLocker) Keys() ([]string, error) {
 	locker.mu.RLock()
 	defer locker.mu.RUnlock()
-	keys := make([]string, len(locker.data))
+	values := locker.values()
+	keys := make([]string, len(values))
 	i := 0
-	for key := range locker.data {
+	for key := range values {
 		keys[i] = key
 		i++
 	}
 	return keys, nil
 }
 
+// values return locked data with applied changes
+func (locker *DataLocker) values() (values map[string]interface{}) {
+	if locker.owner != nil {
+		values = locker.owner.values()
+	} else {
+		values = make(map[string]interface{}, len(locker.data)+len(locker.changes))
+	}
+	for key, value := range locker.data {
+		values[key] = value
+	}
+	for key, value := range locker.changes {
+		values[key] = value
+	}
+	return values
+}
+
 // Injector create new injector for the data scope
 func (locker *DataLocker) Injector(tagname string) app.Injector {
-	return injector.NewMapInjector(tagname, locker.data)
+	locker.mu.RLock()
+	defer locker.mu.RUnlock()
+	return injector.NewMapInjector(tagname, locker.values())
 }
 
-// LockData return new data locker
+// LockData return new nested data locker. It commits changes to the locker.
 func (locker *DataLocker) LockData() app.DataScopeLocker {
 	locker.mu.Lock()
-	return newDataLocker(locker.data, locker.mu.Unlock, locker.parent)
+	nested := newDataLocker(locker.changes, locker.mu.Unlock, locker.parent, locker.watchScope)
+	nested.owner = locker
+	return nested
 }
 
-// Commit unlock parent scope and close locker
+// Watch connect a callback to changes of the locked data scope
+func (locker *DataLocker) Watch(pattern string, callback app.DataWatchCallback) app.WatcherID {
+	return locker.watchScope.Watch(pattern, callback)
+}
+
+// Unwatch disconnect a watch callback
+func (locker *DataLocker) Unwatch(id app.WatcherID) {
+	locker.watchScope.Unwatch(id)
+}
+
+// Commit apply changes atomically, unlock parent scope and close locker
 func (locker *DataLocker) Commit() (err error) {
-	locker.unlockCB()
+	return locker.close(true)
+}
+
+// Rollback discard changes, unlock parent scope and close locker
+func (locker *DataLocker) Rollback() (err error) {
+	return locker.close(false)
+}
+
+func (locker *DataLocker) close(commit bool) (err error) {
+	var (
+		changes   []dataChange
+		inherited []int
+	)
+	locker.mu.Lock()
+	if locker.closed {
+		locker.mu.Unlock()
+		return goaterr.Errorf("DataLocker: locker is closed")
+	}
+	if commit {
+		for key, value := range locker.changes {
+			old, ok := locker.data[key]
+			if !ok && locker.parent != nil {
+				inherited = append(inherited, len(changes))
+			}
+			changes = append(changes, dataChange{key, old, value})
+			locker.data[key] = value
+			if locker.onCommit != nil {
+				locker.onCommit(key)
+			}
+		}
+	}
+	locker.closed = true
 	locker.data = nil
+	locker.changes = nil
+	locker.owner = nil
+	locker.mu.Unlock()
+	locker.unlockCB()
+	if locker.watchers == nil {
+		// nested locker changes are committed to the owner locker
+		return nil
+	}
+	for _, i := range inherited {
+		changes[i].oldValue, _ = locker.parent.Get(changes[i].key)
+	}
+	locker.watchers.notify(changes...)
 	return nil
 }
@@ -29,3 +29,91 @@ func TestDataLocker(t *testing.T) {
 		t.Errorf("expected value equals to 'value' and take %s", value)
 	}
 }
+
+func TestDataLockerBufferWrites(t *testing.T) {
+	var (
+		err    error
+		value  interface{}
+		data   = map[string]interface{}{}
+		scp    = NewDataScope(data)
+		locker app.DataScopeLocker
+	)
+	t.Parallel()
+	locker = scp.LockData()
+	locker.Set("key", "value")
+	if _, ok := data["key"]; ok {
+		t.Errorf("value should be buffered until commit")
+	}
+	if value, err = locker.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "value" {
+		t.Errorf("locker should return own buffered value and take %v", value)
+	}
+	if err = locker.Rollback(); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, err = scp.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("rollback should discard changes and take %v", value)
+	}
+	if err = locker.Commit(); err == nil {
+		t.Errorf("closed locker should return error")
+	}
+}
+
+func TestNestedDataLocker(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+		keys  []string
+		scp   = NewDataScope(map[string]interface{}{
+			"base": "base",
+		})
+	)
+	t.Parallel()
+	locker := scp.LockData()
+	locker.Set("first", "first")
+	nested := locker.LockData()
+	nested.Set("second", "second")
+	if keys, err = nested.Keys(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(keys) != 3 {
+		t.Errorf("expected 3 keys and take %v", keys)
+	}
+	if err = nested.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	rollbacked := locker.LockData()
+	rollbacked.Set("third", "third")
+	if err = rollbacked.Rollback(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = locker.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, err = scp.Get("second"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "second" {
+		t.Errorf("nested changes should be committed with the locker and take %v", value)
+	}
+	if value, err = scp.Get("third"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("rollbacked nested changes should be discarded and take %v", value)
+	}
+}
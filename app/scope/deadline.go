@@ -0,0 +1,94 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+)
+
+// TimeoutError is an error of a scope killed by its deadline
+type TimeoutError struct {
+	Name     string
+	Deadline time.Time
+}
+
+// Error return error message
+func (err *TimeoutError) Error() string {
+	name := err.Name
+	if name == "" {
+		name = "unnamed"
+	}
+	return fmt.Sprintf("scope %s: deadline exceeded (%s)", name, err.Deadline.Format(time.RFC3339))
+}
+
+// IsTimeoutError return true if the error is (or wraps) a scope timeout error
+func IsTimeoutError(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// resolveDeadline return the nearest deadline of the timeout, the deadline and the parent context deadline.
+// It returns the default deadline if no limit is defined.
+func resolveDeadline(parent context.Context, timeout time.Duration, deadline time.Time) time.Time {
+	now := time.Now()
+	result := now.Add(app.DefaultDeadline)
+	if timeout > 0 && now.Add(timeout).Before(result) {
+		result = now.Add(timeout)
+	}
+	if !deadline.IsZero() && deadline.Before(result) {
+		result = deadline
+	}
+	if parent != nil {
+		if parentDeadline, ok := parent.Deadline(); ok && parentDeadline.Before(result) {
+			result = parentDeadline
+		}
+	}
+	return result
+}
+
+// scopeLimit is a context of a sub-scope with own deadline (it never exceeds the parent deadline)
+type scopeLimit struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	name     string
+	deadline time.Time
+	once     sync.Once
+}
+
+// newScopeLimit create a scope limit or return nil if the timeout and the deadline are not defined
+func newScopeLimit(parent context.Context, name string, timeout time.Duration, deadline time.Time) *scopeLimit {
+	if timeout <= 0 && deadline.IsZero() {
+		return nil
+	}
+	limit := &scopeLimit{
+		name:     name,
+		deadline: resolveDeadline(parent, timeout, deadline),
+	}
+	limit.ctx, limit.cancel = context.WithDeadline(parent, limit.deadline)
+	return limit
+}
+
+// watch call onTimeout when the deadline is exceeded
+func (limit *scopeLimit) watch(onTimeout func(error)) {
+	go func() {
+		<-limit.ctx.Done()
+		limit.check(onTimeout)
+	}()
+}
+
+// check call onTimeout with a TimeoutError (once) if the deadline is exceeded
+func (limit *scopeLimit) check(onTimeout func(error)) {
+	if limit.ctx.Err() != context.DeadlineExceeded {
+		return
+	}
+	limit.once.Do(func() {
+		onTimeout(&TimeoutError{
+			Name:     limit.name,
+			Deadline: limit.deadline,
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package scope
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScopeTimeout(t *testing.T) {
+	t.Parallel()
+	scp := NewScope(Params{
+		Name:    "root",
+		Timeout: 10 * time.Millisecond,
+	})
+	select {
+	case <-scp.Context().Done():
+	case <-time.After(time.Second):
+		t.Errorf("scope should be killed by the timeout")
+		return
+	}
+	err := scp.ToError()
+	if !IsTimeoutError(err) {
+		t.Errorf("expected timeout error and take %v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), "root") {
+		t.Errorf("timeout error should contain the scope name and take %v", err)
+	}
+}
+
+func TestChildScopeTimeout(t *testing.T) {
+	t.Parallel()
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{
+		Name:    "task",
+		Timeout: 10 * time.Millisecond,
+	})
+	select {
+	case <-child.Context().Done():
+	case <-time.After(time.Second):
+		t.Errorf("child scope should be killed by the timeout")
+		return
+	}
+	err := child.Close()
+	if !IsTimeoutError(err) || !strings.Contains(err.Error(), "task") {
+		t.Errorf("expected task timeout error and take %v", err)
+	}
+	if !child.IsKilled() {
+		t.Errorf("child scope should be killed")
+	}
+}
+
+func TestChildScopeDeadlineNotExceedParent(t *testing.T) {
+	t.Parallel()
+	parent := NewScope(Params{
+		Timeout: time.Minute,
+	})
+	child := NewChildScope(parent, ChildParams{
+		Timeout: time.Hour,
+	})
+	defer child.Close()
+	parentDeadline, _ := parent.Context().Deadline()
+	childDeadline, ok := child.Context().Deadline()
+	if !ok || childDeadline.After(parentDeadline) {
+		t.Errorf("child deadline %v exceeds parent deadline %v", childDeadline, parentDeadline)
+	}
+}
+
+func TestChildScopeWithoutTimeoutShareContext(t *testing.T) {
+	t.Parallel()
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{})
+	defer child.Close()
+	if child.Context() != parent.Context() {
+		t.Errorf("child scope without limits should share the parent context")
+	}
+}
@@ -2,43 +2,132 @@ package scope
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/goatcms/goatcore/app"
 )
 
+var lastListenerID uint64
+
+// eventListener is a callback connected to an event
+type eventListener struct {
+	id       app.ListenerID
+	priority int
+	callback app.EventCallback
+}
+
 // EventScope is event scope interface
 type EventScope struct {
-	eventsCallbacks map[int][]app.EventCallback
-	mu              sync.RWMutex
+	listeners map[int][]eventListener
+	mu        sync.RWMutex
 }
 
 // NewEventScope create new instance of event scope
 func NewEventScope() app.EventScope {
 	return app.EventScope(&EventScope{
-		eventsCallbacks: make(map[int][]app.EventCallback),
+		listeners: make(map[int][]eventListener),
 	})
 }
 
 // Trigger run all function connected to event
 func (es *EventScope) Trigger(eID int, data interface{}) error {
+	return triggerCallbacks(es.Listeners(eID), data)
+}
+
+// On connect a function to event
+func (es *EventScope) On(eID int, callback app.EventCallback) app.ListenerID {
+	return es.OnPriority(eID, 0, callback)
+}
+
+// OnPriority connect a function to event. Functions with higher priority are run first.
+func (es *EventScope) OnPriority(eID int, priority int, callback app.EventCallback) app.ListenerID {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	listener := newEventListener(priority, callback)
+	es.listeners[eID] = insertListener(es.listeners[eID], listener)
+	return listener.id
+}
+
+// Off disconnect a function
+func (es *EventScope) Off(id app.ListenerID) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	removeListener(es.listeners, id)
+}
+
+// Listeners return functions connected to event
+func (es *EventScope) Listeners(eID int) []app.EventCallback {
 	es.mu.RLock()
 	defer es.mu.RUnlock()
-	for _, onFunc := range es.eventsCallbacks[eID] {
-		if err := onFunc(data); err != nil {
-			return err
-		}
+	return callbacks(es.listeners[eID])
+}
+
+// TriggerAsync run all functions connected to event concurrently. It doesn't stop on error.
+// Every error is appended to the scope and the scope Wait waits for all functions.
+func TriggerAsync(scp app.Scope, eID int, data interface{}) (err error) {
+	callbacks := scp.Listeners(eID)
+	if err = scp.AddTasks(len(callbacks)); err != nil {
+		return err
+	}
+	for _, callback := range callbacks {
+		go func(callback app.EventCallback) {
+			defer scp.DoneTask()
+			if err := callback(data); err != nil {
+				scp.AppendError(err)
+			}
+		}(callback)
 	}
 	return nil
 }
 
-// On connect a function to event
-func (es *EventScope) On(eID int, callback app.EventCallback) {
-	es.mu.Lock()
-	defer es.mu.Unlock()
-	callbacks, ok := es.eventsCallbacks[eID]
-	if !ok {
-		es.eventsCallbacks[eID] = []app.EventCallback{callback}
-		return
+func newEventListener(priority int, callback app.EventCallback) eventListener {
+	return eventListener{
+		id:       app.ListenerID(atomic.AddUint64(&lastListenerID, 1)),
+		priority: priority,
+		callback: callback,
+	}
+}
+
+// insertListener add a listener after listeners with the same or higher priority
+func insertListener(listeners []eventListener, listener eventListener) []eventListener {
+	i := len(listeners)
+	for i > 0 && listeners[i-1].priority < listener.priority {
+		i--
+	}
+	listeners = append(listeners, eventListener{})
+	copy(listeners[i+1:], listeners[i:])
+	listeners[i] = listener
+	return listeners
+}
+
+// removeListener remove a listener by id and return true if it was found
+func removeListener(listeners map[int][]eventListener, id app.ListenerID) bool {
+	for eID, list := range listeners {
+		for i, listener := range list {
+			if listener.id == id {
+				listeners[eID] = append(list[:i:i], list[i+1:]...)
+				return true
+			}
+		}
 	}
-	es.eventsCallbacks[eID] = append(callbacks, callback)
+	return false
+}
+
+func callbacks(listeners []eventListener) []app.EventCallback {
+	result := make([]app.EventCallback, len(listeners))
+	for i, listener := range listeners {
+		result[i] = listener.callback
+	}
+	return result
+}
+
+// triggerCallbacks run callbacks and stop at the first error. The callbacks are a copy
+// of listeners so the functions can connect and disconnect listeners.
+func triggerCallbacks(callbacks []app.EventCallback, data interface{}) error {
+	for _, onFunc := range callbacks {
+		if err := onFunc(data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,6 +1,8 @@
 package scope
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/goatcms/goatcore/app"
@@ -39,3 +41,105 @@ func TestEventScopeErrorStory(t *testing.T) {
 		t.Errorf("Trigger should return error if a function is failed")
 	}
 }
+
+func TestEventScopePriorityStory(t *testing.T) {
+	t.Parallel()
+	var order []string
+	c := NewEventScope()
+	record := func(name string) app.EventCallback {
+		return func(interface{}) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	c.On(app.KillEvent, record("default1"))
+	c.OnPriority(app.KillEvent, 10, record("high"))
+	c.OnPriority(app.KillEvent, -10, record("low"))
+	c.On(app.KillEvent, record("default2"))
+	if err := c.Trigger(app.KillEvent, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if strings.Join(order, ",") != "high,default1,default2,low" {
+		t.Errorf("incorrect callbacks order %v", order)
+	}
+}
+
+func TestEventScopeOffStory(t *testing.T) {
+	t.Parallel()
+	var called int
+	c := NewChildEventScope(NewEventScope())
+	var id app.ListenerID
+	id = c.On(app.KillEvent, func(interface{}) error {
+		called++
+		c.Off(id)
+		return nil
+	})
+	for i := 0; i < 2; i++ {
+		if err := c.Trigger(app.KillEvent, nil); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if called != 1 {
+		t.Errorf("removed function should be called once and it was called %d times", called)
+	}
+	if len(c.Listeners(app.KillEvent)) != 0 {
+		t.Errorf("expected no listeners")
+	}
+}
+
+func TestRegisterEvent(t *testing.T) {
+	t.Parallel()
+	eID := app.RegisterEvent("scope_test_custom_event")
+	if eID != app.RegisterEvent("scope_test_custom_event") {
+		t.Errorf("the same name should return the same event ID")
+	}
+	if eID == app.RegisterEvent("scope_test_other_event") || eID <= app.ConfigChangedEvent {
+		t.Errorf("custom event ID should be unique")
+	}
+	if app.EventName(eID) != "scope_test_custom_event" || app.EventName(app.KillEvent) != "kill" {
+		t.Errorf("incorrect event name")
+	}
+	c := NewEventScope()
+	var data interface{}
+	c.On(eID, func(d interface{}) error {
+		data = d
+		return nil
+	})
+	if err := c.Trigger(eID, "payload"); err != nil {
+		t.Error(err)
+		return
+	}
+	if data != "payload" {
+		t.Errorf("expected payload and take %v", data)
+	}
+}
+
+func TestTriggerAsyncCollectErrors(t *testing.T) {
+	t.Parallel()
+	var (
+		mu     sync.Mutex
+		called int
+	)
+	scp := NewScope(Params{})
+	for i := 0; i < 3; i++ {
+		scp.On(app.KillEvent, func(interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			called++
+			return goaterr.Errorf("error %d", called)
+		})
+	}
+	if err := TriggerAsync(scp, app.KillEvent, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	scp.Wait()
+	if called != 3 {
+		t.Errorf("all functions should be called and %d was called", called)
+	}
+	if errs := scp.Errors(); len(errs) < 3 {
+		t.Errorf("expected all errors collected in the scope and take %v", errs)
+	}
+}
@@ -3,8 +3,10 @@ package scope
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/injector"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -17,6 +19,9 @@ type ParallelScope struct {
 	errorsMU  sync.Mutex
 	waitGroup sync.WaitGroup
 	injectors []app.Injector
+	limit     *scopeLimit
+	id        uint64
+	tasks     int64
 }
 
 // NewParallelScope create new instance of scope
@@ -27,16 +32,25 @@ func NewParallelScope(parent app.Scope, params Params) app.Scope {
 	if params.EventScope == nil {
 		params.EventScope = parent
 	}
-	return &ParallelScope{
+	scp := &ParallelScope{
 		parent:     parent,
 		DataScope:  params.DataScope,
 		EventScope: params.EventScope,
 		injectors:  params.Injectors,
+		limit:      newScopeLimit(parent.Context(), params.Name, params.Timeout, params.Deadline),
 	}
+	if scp.limit != nil {
+		scp.limit.watch(scp.AppendError)
+	}
+	scp.id = register(scp, parent, params.Name, ParallelKind)
+	return scp
 }
 
-// Context return shared context
+// Context return shared context (or own context if the scope has a deadline)
 func (scp *ParallelScope) Context() context.Context {
+	if scp.limit != nil {
+		return scp.limit.ctx
+	}
 	return scp.parent.Context()
 }
 
@@ -45,67 +59,100 @@ func (scp *ParallelScope) Kill() {
 	scp.parent.Kill()
 }
 
-// IsKilled return true if shared context is killed/ended
+// IsKilled return true if shared context is killed/ended (or the scope deadline is exceeded)
 func (scp *ParallelScope) IsKilled() bool {
+	if scp.limit != nil && scp.limit.ctx.Err() != nil {
+		return true
+	}
 	return scp.parent.IsKilled()
 }
 
 // Wait for end of all tasks in child scope
 func (scp *ParallelScope) Wait() (err error) {
 	scp.waitGroup.Wait()
-	return goaterr.ToError(scp.errors)
+	if scp.limit != nil {
+		scp.limit.check(scp.AppendError)
+	}
+	return goaterr.ToError(scp.Errors())
 }
 
 // AddTasks tasks to child scope
 func (scp *ParallelScope) AddTasks(delta int) (err error) {
+	atomic.AddInt64(&scp.tasks, int64(delta))
 	scp.waitGroup.Add(delta)
 	return nil
 }
 
 // DoneTask done one child scope task
 func (scp *ParallelScope) DoneTask() {
+	atomic.AddInt64(&scp.tasks, -1)
 	scp.waitGroup.Done()
 }
 
+// PendingTasks return number of added and not done tasks
+func (scp *ParallelScope) PendingTasks() int64 {
+	return atomic.LoadInt64(&scp.tasks)
+}
+
+// ScopeID return the scope ID in the live scopes registry
+func (scp *ParallelScope) ScopeID() uint64 {
+	return scp.id
+}
+
 // Errors return child scope errors
 func (scp *ParallelScope) Errors() []error {
-	return scp.errors
+	scp.errorsMU.Lock()
+	defer scp.errorsMU.Unlock()
+	if len(scp.errors) == 0 {
+		return nil
+	}
+	return append([]error{}, scp.errors...)
 }
 
 // ToError return error if child scope contains any error
 func (scp *ParallelScope) ToError() error {
-	return goaterr.ToError(scp.errors)
+	return goaterr.ToError(scp.Errors())
 }
 
 // AppendError add error to child and parent scope
 func (scp *ParallelScope) AppendError(err error) {
 	scp.errorsMU.Lock()
-	defer scp.errorsMU.Unlock()
+	scp.errors = append(scp.errors, err)
+	scp.errorsMU.Unlock()
 	scp.Kill()
 	scp.parent.AppendError(err)
-	scp.errors = append(scp.errors, err)
 }
 
 // AppendErrors add errors to child and parent scope
 func (scp *ParallelScope) AppendErrors(errs ...error) {
 	scp.errorsMU.Lock()
-	defer scp.errorsMU.Unlock()
+	scp.errors = append(scp.errors, errs...)
+	scp.errorsMU.Unlock()
 	scp.Kill()
 	scp.parent.AppendErrors(errs...)
-	scp.errors = append(scp.errors, errs...)
 }
 
-// InjectTo insert data to object
+// InjectTo insert data to object. Default values are set for keys not found in the scope and its parents.
 func (scp *ParallelScope) InjectTo(obj interface{}) (err error) {
+	return injector.InjectTo(scp, obj)
+}
+
+// InjectChain insert data to object as a part of an injection chain (see injector.ChainInjector)
+func (scp *ParallelScope) InjectChain(obj interface{}, found injector.Found) (err error) {
 	for _, scpInjector := range scp.injectors {
-		if err = scpInjector.InjectTo(obj); err != nil {
+		if err = injector.InjectChain(scpInjector, obj, found); err != nil {
 			return err
 		}
 	}
-	return scp.parent.InjectTo(obj)
+	return injector.InjectChain(scp.parent, obj, found)
 }
 
 // Close child scope
 func (scp *ParallelScope) Close() (err error) {
-	return scp.Wait()
+	err = scp.Wait()
+	if scp.limit != nil {
+		scp.limit.cancel()
+	}
+	unregister(scp.id)
+	return err
 }
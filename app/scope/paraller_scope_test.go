@@ -160,3 +160,44 @@ func TestParallelScopeInjector(t *testing.T) {
 		t.Errorf("expected result.Value equals to 'value'")
 	}
 }
+
+func TestParallelScopeConcurrentErrors(t *testing.T) {
+	var (
+		parentScope   app.Scope
+		parallelScope app.Scope
+		wg            sync.WaitGroup
+	)
+	t.Parallel()
+	parentScope = NewScope(Params{})
+	parallelScope = NewParallelScope(parentScope, Params{
+		EventScope: parentScope,
+		DataScope:  parentScope,
+	})
+	defer parallelScope.Close()
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			parallelScope.AppendError(fmt.Errorf("error %d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			errs := parallelScope.Errors()
+			for i := range errs {
+				errs[i] = nil
+			}
+		}()
+	}
+	wg.Wait()
+	errs := parallelScope.Errors()
+	if len(errs) != 10 {
+		t.Errorf("expected 10 errors and take %d", len(errs))
+		return
+	}
+	for _, err := range errs {
+		if err == nil {
+			t.Errorf("Errors should return a copy of the errors list")
+			return
+		}
+	}
+}
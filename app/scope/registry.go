@@ -0,0 +1,215 @@
+package scope
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goatcms/goatcore/app"
+)
+
+const (
+	// ScopeKind is a kind of a root scope (created by NewScope)
+	ScopeKind = "scope"
+	// ChildKind is a kind of a child scope
+	ChildKind = "child"
+	// ParallelKind is a kind of a parallel scope
+	ParallelKind = "parallel"
+)
+
+// identified is a scope registered in the live scopes registry
+type identified interface {
+	ScopeID() uint64
+}
+
+// pendingTasksCounter count tasks added by AddTasks and not done yet
+type pendingTasksCounter interface {
+	PendingTasks() int64
+}
+
+type registryEntry struct {
+	mu       sync.Mutex
+	id       uint64
+	parentID uint64
+	name     string
+	kind     string
+	scope    app.Scope
+	closed   bool
+	stop     chan struct{}
+}
+
+var registry = struct {
+	mu      sync.Mutex
+	lastID  uint64
+	entries map[uint64]*registryEntry
+}{
+	entries: map[uint64]*registryEntry{},
+}
+
+// register add the scope to live scopes registry and return its ID. The scope is removed
+// from the registry when it is closed or its context is done (so the registry doesn't keep
+// stopped scopes and their data).
+func register(scp app.Scope, parent interface{}, name, kind string) uint64 {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.lastID++
+	entry := &registryEntry{
+		id:    registry.lastID,
+		name:  name,
+		kind:  kind,
+		scope: scp,
+		stop:  make(chan struct{}),
+	}
+	if parentScope, ok := parent.(identified); ok {
+		entry.parentID = parentScope.ScopeID()
+	}
+	registry.entries[entry.id] = entry
+	go unregisterOnDone(entry.id, scp.Context().Done(), entry.stop)
+	return entry.id
+}
+
+// unregisterOnDone remove the scope from the registry when its context is done
+func unregisterOnDone(id uint64, done <-chan struct{}, stop <-chan struct{}) {
+	select {
+	case <-done:
+		unregister(id)
+	case <-stop:
+	}
+}
+
+// unregister remove the scope from live scopes registry. It waits for the scope inspection
+// so the scope can be destroyed after unregister.
+func unregister(id uint64) {
+	registry.mu.Lock()
+	entry, ok := registry.entries[id]
+	delete(registry.entries, id)
+	registry.mu.Unlock()
+	if !ok {
+		return
+	}
+	entry.mu.Lock()
+	entry.closed = true
+	entry.scope = nil
+	close(entry.stop)
+	entry.mu.Unlock()
+}
+
+// TreeNode describe a live scope
+type TreeNode struct {
+	ID       uint64
+	ParentID uint64
+	Name     string
+	Kind     string
+	Tasks    int64
+	Errors   []error
+	Keys     []string
+}
+
+// Tree is a snapshot of live scopes
+type Tree struct {
+	Nodes []TreeNode
+}
+
+// LiveTree return a snapshot of live scopes (not closed and not killed scopes)
+func LiveTree() (tree Tree) {
+	registry.mu.Lock()
+	entries := make([]*registryEntry, 0, len(registry.entries))
+	for _, entry := range registry.entries {
+		entries = append(entries, entry)
+	}
+	registry.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+	for _, entry := range entries {
+		node, live := entry.inspect()
+		if !live {
+			unregister(entry.id)
+			continue
+		}
+		tree.Nodes = append(tree.Nodes, node)
+	}
+	return tree
+}
+
+// inspect return the scope node. The live is false for closed and killed scopes.
+func (entry *registryEntry) inspect() (node TreeNode, live bool) {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.closed || entry.scope.IsKilled() {
+		return node, false
+	}
+	node = TreeNode{
+		ID:       entry.id,
+		ParentID: entry.parentID,
+		Name:     entry.name,
+		Kind:     entry.kind,
+		Errors:   entry.scope.Errors(),
+	}
+	if counter, ok := entry.scope.(pendingTasksCounter); ok {
+		node.Tasks = counter.PendingTasks()
+	}
+	node.Keys, _ = entry.scope.Keys()
+	sort.Strings(node.Keys)
+	return node, true
+}
+
+// Node return a scope node by ID
+func (tree Tree) Node(id uint64) (node TreeNode, ok bool) {
+	for _, node = range tree.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return node, false
+}
+
+// Roots return scopes without a live parent
+func (tree Tree) Roots() (roots []TreeNode) {
+	for _, node := range tree.Nodes {
+		if _, ok := tree.Node(node.ParentID); !ok {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// Children return child scopes of the scope
+func (tree Tree) Children(id uint64) (children []TreeNode) {
+	for _, node := range tree.Nodes {
+		if node.ParentID == id && node.ID != id {
+			children = append(children, node)
+		}
+	}
+	return children
+}
+
+// WriteTree write scopes as a tree with pending tasks, errors and data keys
+func (tree Tree) WriteTree(w io.Writer) (err error) {
+	var sb strings.Builder
+	for _, node := range tree.Roots() {
+		tree.writeTreeNode(&sb, node, "")
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (tree Tree) writeTreeNode(sb *strings.Builder, node TreeNode, indent string) {
+	name := node.Name
+	if name == "" {
+		name = "unnamed"
+	}
+	desc := fmt.Sprintf("#%d %s [%s, tasks: %d", node.ID, name, node.Kind, node.Tasks)
+	sb.WriteString(indent + desc + "]\n")
+	if len(node.Keys) != 0 {
+		sb.WriteString(indent + "  keys: " + strings.Join(node.Keys, ", ") + "\n")
+	}
+	for _, err := range node.Errors {
+		sb.WriteString(indent + "  error: " + strings.Replace(err.Error(), "\n", " ", -1) + "\n")
+	}
+	for _, child := range tree.Children(node.ID) {
+		tree.writeTreeNode(sb, child, indent+"  ")
+	}
+}
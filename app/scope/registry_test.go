@@ -0,0 +1,143 @@
+package scope
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+func TestLiveTree(t *testing.T) {
+	var (
+		err  error
+		sb   strings.Builder
+		tree Tree
+		node TreeNode
+		ok   bool
+	)
+	t.Parallel()
+	parent := NewScope(Params{
+		Name: "registry-parent",
+	})
+	child := NewChildScope(parent, ChildParams{
+		Name: "registry-child",
+	})
+	if err = child.Set("registry-key", "value"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = child.AddTasks(2); err != nil {
+		t.Error(err)
+		return
+	}
+	parentID := parent.(identified).ScopeID()
+	childID := child.(identified).ScopeID()
+	tree = LiveTree()
+	if node, ok = tree.Node(childID); !ok {
+		t.Errorf("expected child scope in live tree")
+		return
+	}
+	if node.ParentID != parentID {
+		t.Errorf("expected parent ID %d and take %d", parentID, node.ParentID)
+	}
+	if node.Tasks != 2 {
+		t.Errorf("expected 2 pending tasks and take %d", node.Tasks)
+	}
+	if err = tree.WriteTree(&sb); err != nil {
+		t.Error(err)
+		return
+	}
+	out := sb.String()
+	if !strings.Contains(out, "registry-parent [scope, tasks: 1]") || !strings.Contains(out, "  #") ||
+		!strings.Contains(out, "registry-child [child, tasks: 2]") || !strings.Contains(out, "registry-key") {
+		t.Errorf("unexpected tree output: %s", out)
+	}
+	child.DoneTask()
+	child.DoneTask()
+	child.Close()
+	if _, ok = LiveTree().Node(childID); ok {
+		t.Errorf("closed scope should be removed from live tree")
+	}
+	if _, ok = LiveTree().Node(parentID); !ok {
+		t.Errorf("expected parent scope in live tree")
+	}
+	parent.Close()
+	if _, ok = LiveTree().Node(parentID); ok {
+		t.Errorf("closed scope should be removed from live tree")
+	}
+}
+
+func TestLiveTreeDropKilledScopes(t *testing.T) {
+	t.Parallel()
+	parent := NewScope(Params{
+		Name: "registry-killed-parent",
+	})
+	child := NewChildScope(parent, ChildParams{
+		Name: "registry-killed-child",
+	})
+	parentID := parent.(identified).ScopeID()
+	childID := child.(identified).ScopeID()
+	child.AppendError(goaterr.Errorf("registry error"))
+	for _, id := range []uint64{parentID, childID} {
+		if !isUnregistered(id, time.Second) {
+			t.Errorf("the registry shouldn't keep killed scope #%d", id)
+		}
+		if _, ok := LiveTree().Node(id); ok {
+			t.Errorf("killed scope #%d should be removed from live tree", id)
+		}
+	}
+	child.Close()
+}
+
+func TestReleaseScope(t *testing.T) {
+	var killed bool
+	t.Parallel()
+	parent := NewScope(Params{
+		Name: "registry-release-parent",
+	})
+	defer parent.Close()
+	parent.On(app.KillEvent, func(interface{}) error {
+		killed = true
+		return nil
+	})
+	separated := NewScope(Params{
+		DataScope:  parent,
+		EventScope: parent,
+		Parent:     parent,
+		Name:       "registry-release-separated",
+	})
+	separatedID := separated.(identified).ScopeID()
+	if _, ok := LiveTree().Node(separatedID); !ok {
+		t.Errorf("expected live separated scope")
+		return
+	}
+	Release(separated)
+	if _, ok := LiveTree().Node(separatedID); ok {
+		t.Errorf("released scope should be removed from live tree")
+	}
+	if !isUnregistered(separatedID, time.Second) {
+		t.Errorf("the registry shouldn't keep released scope")
+	}
+	if killed {
+		t.Errorf("release shouldn't trigger KillEvent of the shared event scope")
+	}
+	if parent.IsKilled() {
+		t.Errorf("release shouldn't kill the parent scope")
+	}
+}
+
+func isUnregistered(id uint64, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		registry.mu.Lock()
+		_, ok := registry.entries[id]
+		registry.mu.Unlock()
+		if !ok {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
@@ -1,24 +1,38 @@
 package scope
 
 import (
+	"time"
+
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/app/injector"
 )
 
-// ChildParams describe child scope
+// ChildParams describe child scope. The Name is used in the timeout error.
+// The Timeout and the Deadline limit the child scope lifetime (it never exceeds the parent deadline).
+// The default DataScope is a child data scope (writes are visible immediately). Transactions are
+// opt-in: set DataScope to NewTransactionDataScope to apply changes on Close only.
 type ChildParams struct {
 	DataScope  app.DataScope
 	EventScope app.EventScope
 	Injectors  []app.Injector
+	Name       string
+	Timeout    time.Duration
+	Deadline   time.Time
 }
 
-// Params describe scope
+// Params describe scope. The Name is used in the timeout error.
+// The Timeout and the Deadline limit the scope lifetime (they are used when SyncScope is not defined).
+// The scope is killed with the Parent sync scope (KillEvent is triggered if EventScope is not defined).
 type Params struct {
 	DataScope  app.DataScope
 	EventScope app.EventScope
 	Injectors  []app.Injector
 	SyncScope  app.SyncScope
+	Parent     app.SyncScope
 	Tag        string
+	Name       string
+	Timeout    time.Duration
+	Deadline   time.Time
 }
 
 // Scope is global scope interface
@@ -27,6 +41,7 @@ type Scope struct {
 	app.EventScope
 	app.DataScope
 	app.SyncScope
+	id uint64
 }
 
 // NewScope create new instance of scope
@@ -45,34 +60,91 @@ func NewScope(params Params) app.Scope {
 	} else {
 		scopeInjector = injector.NewNilInjector()
 	}
-	if params.EventScope == nil {
+	ownEvents := params.EventScope == nil
+	if ownEvents {
 		params.EventScope = NewEventScope()
 	}
-	if params.SyncScope == nil {
-		params.SyncScope = NewSyncScope(nil)
+	ownSync := params.SyncScope == nil
+	if ownSync {
+		params.SyncScope = NewDeadlineSyncScope(params.Parent, params.Name, params.Timeout, params.Deadline)
+		if params.Parent != nil && ownEvents {
+			go killWithParent(params.Parent, params.SyncScope, params.EventScope)
+		}
 	}
-	return &Scope{
+	scp := &Scope{
 		EventScope: params.EventScope,
 		DataScope:  params.DataScope,
 		Injector:   scopeInjector,
 		SyncScope:  params.SyncScope,
 	}
+	if ownSync {
+		// a scope with shared sync scope is a view of other scope (it is not registered)
+		name := params.Name
+		if name == "" {
+			name = params.Tag
+		}
+		scp.id = register(scp, params.Parent, name, ScopeKind)
+	}
+	return scp
+}
+
+// InjectChain insert data to object as a part of an injection chain (see injector.ChainInjector)
+func (scp *Scope) InjectChain(obj interface{}, found injector.Found) error {
+	return injector.InjectChain(scp.Injector, obj, found)
+}
+
+// ScopeID return the scope ID in the live scopes registry (or ID of the scope sharing the sync scope)
+func (scp *Scope) ScopeID() uint64 {
+	if scp.id != 0 {
+		return scp.id
+	}
+	if sync, ok := scp.SyncScope.(identified); ok {
+		return sync.ScopeID()
+	}
+	return 0
+}
+
+// PendingTasks return number of added and not done tasks
+func (scp *Scope) PendingTasks() int64 {
+	if counter, ok := scp.SyncScope.(pendingTasksCounter); ok {
+		return counter.PendingTasks()
+	}
+	return 0
 }
 
-// Close scope
+// Close scope. It commits (or rollbacks on error) a transaction data scope
+// and triggers CommitEvent (or RollbackEvent).
 func (scp *Scope) Close() (err error) {
+	tx, isTransaction := scp.DataScope.(dataTransaction)
 	if err = scp.Wait(); err != nil {
 		scp.Kill()
+		if isTransaction {
+			scp.AppendError(tx.Rollback())
+		}
 		scp.AppendError(scp.Trigger(app.RollbackEvent, scp))
 		scp.destroy()
 		return scp.ToError()
 	}
 	scp.Kill()
+	if isTransaction {
+		scp.AppendError(tx.Commit())
+	}
 	scp.AppendError(scp.Trigger(app.CommitEvent, scp))
 	scp.destroy()
 	return scp.ToError()
 }
 
+// Release stop a scope finished without Close (like a scope sharing events with its parent).
+// It kills the scope sync scope without KillEvent and removes the scope from the live scopes registry.
+func Release(scp app.Scope) {
+	s, ok := scp.(*Scope)
+	if !ok || s.id == 0 {
+		return
+	}
+	s.SyncScope.Kill()
+	unregister(s.id)
+}
+
 // Kill scope
 func (scp *Scope) Kill() {
 	scp.SyncScope.Kill()
@@ -81,7 +153,16 @@ func (scp *Scope) Kill() {
 	}
 }
 
+// killWithParent trigger KillEvent when the scope is killed by the parent
+func killWithParent(parent app.SyncScope, sync app.SyncScope, events app.EventScope) {
+	<-sync.Context().Done()
+	if parent.IsKilled() {
+		sync.AppendError(events.Trigger(app.KillEvent, nil))
+	}
+}
+
 func (scp *Scope) destroy() {
+	unregister(scp.id)
 	scp.EventScope = nil
 	scp.DataScope = nil
 	scp.Injector = nil
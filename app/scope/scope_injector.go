@@ -2,9 +2,9 @@ package scope
 
 import (
 	"reflect"
-	"strings"
 
 	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/injector"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
@@ -22,42 +22,44 @@ func NewScopeInjector(tagname string, ds app.DataScope) app.Injector {
 	})
 }
 
-// InjectTo inject data from all injectors
+// InjectTo inject data from all injectors. String values are converted to a field type
+// and the default value (`default=` tag option) is used for undefined keys.
 func (ds ScopeInjector) InjectTo(obj interface{}) (err error) {
+	return injector.InjectTo(ds, obj)
+}
+
+// InjectChain inject scope data as a part of an injection chain (see injector.ChainInjector)
+func (ds ScopeInjector) InjectChain(obj interface{}, found injector.Found) (err error) {
 	var newValue interface{}
+	found.Source(ds.tagname)
 	structValue := reflect.ValueOf(obj).Elem()
 	for i := 0; i < structValue.NumField(); i++ {
-		var isRequired = true
 		valueField := structValue.Field(i)
 		structField := structValue.Type().Field(i)
-		key := structField.Tag.Get(ds.tagname)
-		if key == "" {
+		tagValue := structField.Tag.Get(ds.tagname)
+		if tagValue == "" {
 			continue
 		}
-		if strings.HasPrefix(key, "?") {
-			isRequired = false
-			key = key[1:]
-		}
+		tag := injector.ParseTag(tagValue)
 		if !valueField.IsValid() {
 			return goaterr.Errorf("ScopeInjector.InjectTo: %s is not valid", structField.Name)
 		}
 		if !valueField.CanSet() {
 			return goaterr.Errorf("ScopeInjector.InjectTo: Cannot set %s field value", structField.Name)
 		}
-		if newValue, err = ds.data.Get(key); err != nil {
+		if newValue, err = ds.data.Get(tag.Key); err != nil {
 			return err
 		}
 		if newValue == nil {
-			if !isRequired {
+			if tag.HasDefault || !tag.Required {
 				continue
 			}
-			return goaterr.Errorf("value for %s is unknown", key)
+			return goaterr.Errorf("value for %s is unknown", tag.Key)
 		}
-		if newValue == nil {
-			return goaterr.Errorf("ScopeInjector.InjectTo: dependency instance can not be nil (%s)", key)
+		if err = injector.SetField(valueField, newValue); err != nil {
+			return goaterr.Wrapf("ScopeInjector.InjectTo: incorrect %s value", err, tag.Key)
 		}
-		refValue := reflect.ValueOf(newValue)
-		valueField.Set(refValue)
+		found.Add(ds.tagname, tag.Key)
 	}
 	return nil
 }
@@ -2,6 +2,9 @@ package scope
 
 import (
 	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/app/injector"
 )
 
 func TestSimpleInject(t *testing.T) {
@@ -23,3 +26,63 @@ func TestSimpleInject(t *testing.T) {
 		t.Error("MapInjector didn't inject 'SomeStringValue' to SomeString field")
 	}
 }
+
+func TestInjectDefaultValue(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Silent  bool   `tagname:"?silent,default=true"`
+		Verbose bool   `tagname:"?verbose,default=true"`
+		Port    int    `tagname:"?port,default=8080"`
+		Name    string `tagname:"?name"`
+	}
+	dataScope := NewDataScope(map[string]interface{}{
+		"verbose": "false",
+	})
+	if err := NewScopeInjector("tagname", dataScope).InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if !object.Silent || object.Verbose || object.Port != 8080 || object.Name != "" {
+		t.Errorf("incorrect values %+v", object)
+	}
+}
+
+func TestScopeInjectDefaultValue(t *testing.T) {
+	t.Parallel()
+	var object struct {
+		Silent  bool   `tagname:"?silent,default=true"`
+		Verbose bool   `tagname:"?verbose,default=true"`
+		Port    int    `tagname:"?port,default=8080"`
+		Limit   int    `tagname:"?limit,default=10"`
+		Name    string `tagname:"?name,default=name"`
+	}
+	parent := NewScope(Params{
+		Tag: "tagname",
+		DataScope: NewDataScope(map[string]interface{}{
+			"verbose": "false",
+		}),
+		Injectors: []app.Injector{
+			injector.NewMapInjector("tagname", map[string]interface{}{
+				"silent": false,
+			}),
+		},
+	})
+	child := NewChildScope(parent, ChildParams{
+		Injectors: []app.Injector{
+			injector.NewMapInjector("tagname", map[string]interface{}{
+				"port": 0,
+			}),
+		},
+	})
+	defer child.Close()
+	if err := child.InjectTo(&object); err != nil {
+		t.Error(err)
+		return
+	}
+	if object.Silent || object.Verbose || object.Port != 0 {
+		t.Errorf("injected values must override defaults and take %+v", object)
+	}
+	if object.Limit != 10 || object.Name != "name" {
+		t.Errorf("expected default values for undefined keys and take %+v", object)
+	}
+}
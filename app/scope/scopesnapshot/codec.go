@@ -0,0 +1,206 @@
+package scopesnapshot
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// Codec encode and decode values of a type
+type Codec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// JSONCodec encode and decode values of a type with encoding/json
+type JSONCodec struct {
+	typ reflect.Type
+}
+
+// NewJSONCodec create a JSON codec for the type
+func NewJSONCodec(typ reflect.Type) Codec {
+	return JSONCodec{
+		typ: typ,
+	}
+}
+
+// Encode value to JSON
+func (codec JSONCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode JSON to a value of the codec type
+func (codec JSONCodec) Decode(data []byte) (interface{}, error) {
+	ptr := reflect.New(codec.typ)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+type registeredCodec struct {
+	name  string
+	codec Codec
+}
+
+var registry = struct {
+	mu     sync.RWMutex
+	types  map[reflect.Type]registeredCodec
+	codecs map[string]Codec
+}{
+	types:  map[reflect.Type]registeredCodec{},
+	codecs: map[string]Codec{},
+}
+
+func init() {
+	for _, value := range []interface{}{
+		"", false,
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+		time.Duration(0), time.Time{},
+		[]string{}, map[string]string{},
+	} {
+		typ := reflect.TypeOf(value)
+		RegisterCodec(typ.String(), typ, NewJSONCodec(typ))
+	}
+	RegisterCodec("[]interface {}", reflect.TypeOf([]interface{}{}), ListCodec{})
+	RegisterCodec("map[string]interface {}", reflect.TypeOf(map[string]interface{}{}), MapCodec{})
+}
+
+// unsupportedError is returned for a value without registered codec
+type unsupportedError struct {
+	typ reflect.Type
+}
+
+func (err unsupportedError) Error() string {
+	return "scopesnapshot: codec for " + err.typ.String() + " type is not registered"
+}
+
+// ListCodec encode and decode []interface{}. Elements are encoded with their registered
+// codecs (so element types are preserved).
+type ListCodec struct{}
+
+// Encode list elements
+func (codec ListCodec) Encode(value interface{}) (data []byte, err error) {
+	list := value.([]interface{})
+	rows := make([]snapshotValue, len(list))
+	for i, elem := range list {
+		if rows[i], err = encodeValue(elem); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(rows)
+}
+
+// Decode list elements
+func (codec ListCodec) Decode(data []byte) (interface{}, error) {
+	var rows []snapshotValue
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, len(rows))
+	for i, row := range rows {
+		var err error
+		if list[i], err = decodeValue(row); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// MapCodec encode and decode map[string]interface{}. Values are encoded with their registered
+// codecs (so value types are preserved).
+type MapCodec struct{}
+
+// Encode map values
+func (codec MapCodec) Encode(value interface{}) (data []byte, err error) {
+	rows := map[string]snapshotValue{}
+	for key, elem := range value.(map[string]interface{}) {
+		if rows[key], err = encodeValue(elem); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(rows)
+}
+
+// Decode map values
+func (codec MapCodec) Decode(data []byte) (interface{}, error) {
+	var rows map[string]snapshotValue
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(rows))
+	for key, row := range rows {
+		var err error
+		if result[key], err = decodeValue(row); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// encodeValue encode a value with its registered codec. A nil value is encoded without codec.
+func encodeValue(value interface{}) (row snapshotValue, err error) {
+	if value == nil {
+		return snapshotValue{Value: json.RawMessage("null")}, nil
+	}
+	name, codec, ok := codecForType(reflect.TypeOf(value))
+	if !ok {
+		return row, unsupportedError{reflect.TypeOf(value)}
+	}
+	if row.Value, err = codec.Encode(value); err != nil {
+		return row, err
+	}
+	row.Codec = name
+	return row, nil
+}
+
+// decodeValue decode a value encoded by encodeValue
+func decodeValue(row snapshotValue) (interface{}, error) {
+	if row.Codec == "" {
+		return nil, nil
+	}
+	codec, ok := codecByName(row.Codec)
+	if !ok {
+		return nil, goaterr.Errorf("unknown codec %s", row.Codec)
+	}
+	return codec.Decode(row.Value)
+}
+
+// RegisterCodec add a codec for values of the type. The name identify the codec in snapshots
+// so it must be stable. Basic types (string, bool, numbers, time.Duration, time.Time,
+// []string, map[string]string, []interface{} and map[string]interface{}) are registered by default.
+func RegisterCodec(name string, typ reflect.Type, codec Codec) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, ok := registry.codecs[name]; ok {
+		return goaterr.Errorf("scopesnapshot.RegisterCodec: codec %s is registered", name)
+	}
+	if current, ok := registry.types[typ]; ok {
+		return goaterr.Errorf("scopesnapshot.RegisterCodec: codec for %s type is registered (as %s)", typ, current.name)
+	}
+	registry.codecs[name] = codec
+	registry.types[typ] = registeredCodec{
+		name:  name,
+		codec: codec,
+	}
+	return nil
+}
+
+func codecForType(typ reflect.Type) (name string, codec Codec, ok bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	registered, ok := registry.types[typ]
+	return registered.name, registered.codec, ok
+}
+
+func codecByName(name string) (codec Codec, ok bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	codec, ok = registry.codecs[name]
+	return codec, ok
+}
@@ -0,0 +1,106 @@
+package scopesnapshot
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/filesystem"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// snapshot is a serialized data scope
+type snapshot struct {
+	Values  map[string]snapshotValue `json:"values"`
+	Skipped []string                 `json:"skipped,omitempty"`
+}
+
+type snapshotValue struct {
+	Codec string          `json:"codec"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Marshal serialize data scope values. Values without registered codec (like services
+// and lists or maps containing them) are skipped and their keys are returned.
+func Marshal(ds app.DataScope) (data []byte, skipped []string, err error) {
+	var (
+		keys   []string
+		value  interface{}
+		row    snapshotValue
+		result = snapshot{
+			Values: map[string]snapshotValue{},
+		}
+	)
+	locker := ds.LockData()
+	defer locker.Rollback()
+	if keys, err = locker.Keys(); err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if value, err = locker.Get(key); err != nil {
+			return nil, nil, err
+		}
+		if value == nil {
+			continue
+		}
+		if row, err = encodeValue(value); err != nil {
+			if _, ok := err.(unsupportedError); ok {
+				skipped = append(skipped, key)
+				continue
+			}
+			return nil, nil, goaterr.Wrapf("scopesnapshot.Marshal: can not encode %s value", err, key)
+		}
+		result.Values[key] = row
+	}
+	result.Skipped = skipped
+	if data, err = json.Marshal(result); err != nil {
+		return nil, nil, err
+	}
+	return data, skipped, nil
+}
+
+// Unmarshal restore serialized values to the data scope. Values are set atomically.
+func Unmarshal(ds app.DataScope, data []byte) (err error) {
+	var (
+		source  snapshot
+		decoded = map[string]interface{}{}
+	)
+	if err = json.Unmarshal(data, &source); err != nil {
+		return goaterr.Wrapf("scopesnapshot.Unmarshal: incorrect snapshot", err)
+	}
+	for key, row := range source.Values {
+		if decoded[key], err = decodeValue(row); err != nil {
+			return goaterr.Wrapf("scopesnapshot.Unmarshal: can not decode %s value", err, key)
+		}
+	}
+	locker := ds.LockData()
+	for key, value := range decoded {
+		if err = locker.Set(key, value); err != nil {
+			locker.Rollback()
+			return err
+		}
+	}
+	return locker.Commit()
+}
+
+// Snapshot write data scope values to a file. It returns keys of skipped values.
+func Snapshot(ds app.DataScope, fs filesystem.Filespace, path string) (skipped []string, err error) {
+	var data []byte
+	if data, skipped, err = Marshal(ds); err != nil {
+		return nil, err
+	}
+	if err = fs.WriteFile(path, data, filesystem.DefaultUnixFileMode); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
+// Restore read data scope values from a snapshot file
+func Restore(ds app.DataScope, fs filesystem.Filespace, path string) (err error) {
+	var data []byte
+	if data, err = fs.ReadFile(path); err != nil {
+		return err
+	}
+	return Unmarshal(ds, data)
+}
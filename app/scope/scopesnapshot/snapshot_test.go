@@ -0,0 +1,139 @@
+package scopesnapshot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app/scope"
+	"github.com/goatcms/goatcore/filesystem/filespace/memfs"
+)
+
+type testState struct {
+	Step  int      `json:"step"`
+	Names []string `json:"names"`
+}
+
+type testService struct{}
+
+func init() {
+	RegisterCodec("scopesnapshot.testState", reflect.TypeOf(testState{}), NewJSONCodec(reflect.TypeOf(testState{})))
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	t.Parallel()
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	source := scope.NewDataScope(map[string]interface{}{
+		"string":   "value",
+		"int":      12,
+		"duration": time.Minute,
+		"list":     []string{"a", "b"},
+		"state": testState{
+			Step:  3,
+			Names: []string{"build"},
+		},
+		"service": &testService{},
+	})
+	skipped, err := Snapshot(source, fs, "/snapshot.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(skipped) != 1 || skipped[0] != "service" {
+		t.Errorf("expected skipped service and take %v", skipped)
+	}
+	restored := scope.NewDataScope(map[string]interface{}{})
+	if err = Restore(restored, fs, "/snapshot.json"); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, key := range []string{"string", "int", "duration", "list", "state"} {
+		expected, _ := source.Get(key)
+		value, _ := restored.Get(key)
+		if !reflect.DeepEqual(expected, value) {
+			t.Errorf("expected %s equals to %#v and take %#v", key, expected, value)
+		}
+	}
+	if value, _ := restored.Get("service"); value != nil {
+		t.Errorf("service shouldn't be restored")
+	}
+}
+
+func TestRestoreUnknownCodec(t *testing.T) {
+	t.Parallel()
+	err := Unmarshal(scope.NewDataScope(map[string]interface{}{}), []byte(`{"values":{"key":{"codec":"unknown","value":1}}}`))
+	if err == nil || !strings.Contains(err.Error(), "unknown codec") {
+		t.Errorf("expected unknown codec error and take %v", err)
+	}
+}
+
+func TestRegisterCodecTwice(t *testing.T) {
+	t.Parallel()
+	if err := RegisterCodec("string", reflect.TypeOf(testService{}), NewJSONCodec(reflect.TypeOf(testService{}))); err == nil {
+		t.Errorf("expected error for registered codec name")
+	}
+	if err := RegisterCodec("scopesnapshot.otherString", reflect.TypeOf(""), NewJSONCodec(reflect.TypeOf(""))); err == nil {
+		t.Errorf("expected error for registered codec type")
+	}
+}
+
+func TestNestedContainersKeepTypes(t *testing.T) {
+	t.Parallel()
+	source := scope.NewDataScope(map[string]interface{}{
+		"list": []interface{}{1, "a", nil, []interface{}{int64(2)}},
+		"map": map[string]interface{}{
+			"int":    3,
+			"nested": map[string]interface{}{"uint": uint8(4)},
+		},
+	})
+	data, skipped, err := Marshal(source)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(skipped) != 0 {
+		t.Errorf("expected no skipped keys and take %v", skipped)
+	}
+	restored := scope.NewDataScope(map[string]interface{}{})
+	if err = Unmarshal(restored, data); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, key := range []string{"list", "map"} {
+		expected, _ := source.Get(key)
+		value, _ := restored.Get(key)
+		if !reflect.DeepEqual(expected, value) {
+			t.Errorf("expected %s equals to %#v and take %#v", key, expected, value)
+		}
+	}
+}
+
+func TestSkipContainerWithUnsupportedValue(t *testing.T) {
+	t.Parallel()
+	source := scope.NewDataScope(map[string]interface{}{
+		"services": []interface{}{&testService{}},
+		"config":   map[string]interface{}{"service": &testService{}},
+		"value":    "value",
+	})
+	data, skipped, err := Marshal(source)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(skipped) != 2 || skipped[0] != "config" || skipped[1] != "services" {
+		t.Errorf("expected skipped config and services and take %v", skipped)
+	}
+	restored := scope.NewDataScope(map[string]interface{}{})
+	if err = Unmarshal(restored, data); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, _ := restored.Get("value"); value != "value" {
+		t.Errorf("expected restored value and take %v", value)
+	}
+}
@@ -3,6 +3,8 @@ package scope
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/goatcms/goatcore/app"
 	"github.com/goatcms/goatcore/varutil/goaterr"
@@ -14,14 +16,31 @@ type SyncScope struct {
 	lifecycle *jobsync.Lifecycle
 	waitGroup *sync.WaitGroup
 	parent    app.SyncScope
+	name      string
+	deadline  time.Time
+	tasks     int64
 }
 
 // NewSyncScope create new instance of error scope
 func NewSyncScope(parent app.SyncScope) app.SyncScope {
+	return NewDeadlineSyncScope(parent, "", 0, time.Time{})
+}
+
+// NewDeadlineSyncScope create new instance of error scope killed when the timeout or the deadline
+// is exceeded (the nearest one). The deadline never exceeds the parent deadline.
+// The scope context is derived from the parent context (the scope is killed with the parent).
+func NewDeadlineSyncScope(parent app.SyncScope, name string, timeout time.Duration, deadline time.Time) app.SyncScope {
+	var parentCtx = context.Background()
+	if parent != nil {
+		parentCtx = parent.Context()
+	}
+	deadline = resolveDeadline(parentCtx, timeout, deadline)
 	return &SyncScope{
-		lifecycle: jobsync.NewLifecycle(app.DefaultDeadline, true),
+		lifecycle: jobsync.NewChildLifecycle(parentCtx, deadline, true),
 		waitGroup: &sync.WaitGroup{},
 		parent:    parent,
+		name:      name,
+		deadline:  deadline,
 	}
 }
 
@@ -35,16 +54,9 @@ func (s *SyncScope) Kill() {
 	s.lifecycle.Kill()
 }
 
-// IsKilled check if scope is killed
+// IsKilled check if scope is killed (or its parent is killed)
 func (s *SyncScope) IsKilled() bool {
-	if s.lifecycle.IsKilled() {
-		return true
-	}
-	if s.parent != nil && s.parent.IsKilled() {
-		s.lifecycle.Kill()
-		return true
-	}
-	return false
+	return s.lifecycle.IsKilled()
 }
 
 // ToError return scope error object or nil if does't contains a error
@@ -52,9 +64,18 @@ func (s *SyncScope) ToError() error {
 	return goaterr.ToError(s.Errors())
 }
 
-// Errors return scope errors
+// Errors return scope errors. The exceeded deadline is returned as TimeoutError.
 func (s *SyncScope) Errors() []error {
-	return s.lifecycle.Errors()
+	errs := append([]error{}, s.lifecycle.Errors()...)
+	for i, err := range errs {
+		if err == context.DeadlineExceeded {
+			errs[i] = &TimeoutError{
+				Name:     s.name,
+				Deadline: s.deadline,
+			}
+		}
+	}
+	return errs
 }
 
 // AppendError append error to scope (skip nil error)
@@ -83,11 +104,18 @@ func (s *SyncScope) AddTasks(delta int) (err error) {
 	if s.IsKilled() {
 		return s.ToError()
 	}
+	atomic.AddInt64(&s.tasks, int64(delta))
 	s.waitGroup.Add(delta)
 	return nil
 }
 
 // DoneTask mark single task as done
 func (s *SyncScope) DoneTask() {
+	atomic.AddInt64(&s.tasks, -1)
 	s.waitGroup.Done()
 }
+
+// PendingTasks return number of added and not done tasks
+func (s *SyncScope) PendingTasks() int64 {
+	return atomic.LoadInt64(&s.tasks)
+}
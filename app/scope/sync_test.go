@@ -0,0 +1,93 @@
+package scope
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/app"
+)
+
+func TestSyncScopeKillCascade(t *testing.T) {
+	t.Parallel()
+	parent := NewSyncScope(nil)
+	child := NewSyncScope(parent)
+	grandchild := NewSyncScope(child)
+	parent.Kill()
+	select {
+	case <-grandchild.Context().Done():
+	case <-time.After(time.Second):
+		t.Errorf("kill should be propagated to the grandchild context")
+		return
+	}
+	if !child.IsKilled() || !grandchild.IsKilled() {
+		t.Errorf("children should be killed")
+	}
+}
+
+func TestSyncScopeChildKillNotPropagateUp(t *testing.T) {
+	t.Parallel()
+	parent := NewSyncScope(nil)
+	child := NewSyncScope(parent)
+	child.Kill()
+	if parent.IsKilled() {
+		t.Errorf("parent shouldn't be killed by the child")
+	}
+}
+
+func TestScopeKillEventFromParent(t *testing.T) {
+	t.Parallel()
+	killed := make(chan struct{})
+	parent := NewScope(Params{})
+	child := NewScope(Params{
+		Parent: parent,
+	})
+	child.On(app.KillEvent, func(interface{}) error {
+		close(killed)
+		return nil
+	})
+	parent.Kill()
+	select {
+	case <-killed:
+	case <-time.After(time.Second):
+		t.Errorf("KillEvent should be triggered for the child scope")
+	}
+}
+
+func TestChildScopeKillEventFromParent(t *testing.T) {
+	t.Parallel()
+	var called int
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{})
+	child.On(app.KillEvent, func(interface{}) error {
+		called++
+		return nil
+	})
+	parent.Kill()
+	child.Close()
+	if called != 1 {
+		t.Errorf("KillEvent should be triggered once for the child scope callbacks (called %d times)", called)
+	}
+	parent.Kill()
+	if called != 1 {
+		t.Errorf("closed child shouldn't get parent events")
+	}
+}
+
+func TestChildScopeKillEventTriggeredDirectly(t *testing.T) {
+	t.Parallel()
+	var called int
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{})
+	child.On(app.KillEvent, func(interface{}) error {
+		called++
+		return nil
+	})
+	if err := child.Trigger(app.KillEvent, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if called != 1 {
+		t.Errorf("KillEvent should be triggered once for the child scope callbacks (called %d times)", called)
+	}
+	child.Close()
+}
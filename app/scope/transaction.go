@@ -0,0 +1,110 @@
+package scope
+
+import (
+	"sync"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil"
+)
+
+// dataTransaction is a data scope with commit and rollback
+type dataTransaction interface {
+	Commit() error
+	Rollback() error
+}
+
+// TransactionDataScope buffer writes to a parent data scope. Changes are applied to
+// the parent atomically on Commit and discarded on Rollback. It is opt-in (default data
+// scopes are not transactional). Scope.Close and ChildScope.Close commit or rollback
+// the transaction data scope automatically.
+type TransactionDataScope struct {
+	parent  app.DataScope
+	changes map[string]interface{}
+	mu      sync.RWMutex
+}
+
+// NewTransactionDataScope create new instance of transaction data scope
+func NewTransactionDataScope(parent app.DataScope) app.DataScope {
+	return app.DataScope(&TransactionDataScope{
+		parent:  parent,
+		changes: map[string]interface{}{},
+	})
+}
+
+// Set new scope value. The value is visible for the parent after commit.
+func (tx *TransactionDataScope) Set(key string, v interface{}) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.changes[key] = v
+	return nil
+}
+
+// Get get value from context
+func (tx *TransactionDataScope) Get(key string) (value interface{}, err error) {
+	var ok bool
+	tx.mu.RLock()
+	value, ok = tx.changes[key]
+	tx.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+	return tx.parent.Get(key)
+}
+
+// Keys return parent keys and keys of uncommitted values
+func (tx *TransactionDataScope) Keys() (keys []string, err error) {
+	if keys, err = tx.parent.Keys(); err != nil {
+		return nil, err
+	}
+	tx.mu.RLock()
+	defer tx.mu.RUnlock()
+	for key := range tx.changes {
+		if !varutil.IsArrContainStr(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// LockData return new data locker. It commits changes to the transaction.
+func (tx *TransactionDataScope) LockData() (locker app.DataScopeLocker) {
+	tx.mu.Lock()
+	return newDataLocker(tx.changes, tx.mu.Unlock, tx.parent, tx)
+}
+
+// Watch connect a callback to changes of a key (or a prefix ending with '*').
+// The transaction changes are notified after Commit.
+func (tx *TransactionDataScope) Watch(pattern string, callback app.DataWatchCallback) app.WatcherID {
+	return tx.parent.Watch(pattern, callback)
+}
+
+// Unwatch disconnect a watch callback
+func (tx *TransactionDataScope) Unwatch(id app.WatcherID) {
+	tx.parent.Unwatch(id)
+}
+
+// Commit apply changes to the parent data scope atomically and start a new transaction
+func (tx *TransactionDataScope) Commit() (err error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	locker := tx.parent.LockData()
+	for key, value := range tx.changes {
+		if err = locker.Set(key, value); err != nil {
+			locker.Rollback()
+			return err
+		}
+	}
+	if err = locker.Commit(); err != nil {
+		return err
+	}
+	tx.changes = map[string]interface{}{}
+	return nil
+}
+
+// Rollback discard changes and start a new transaction
+func (tx *TransactionDataScope) Rollback() (err error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.changes = map[string]interface{}{}
+	return nil
+}
@@ -0,0 +1,179 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/app"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+func TestTransactionDataScope(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+	)
+	t.Parallel()
+	parent := NewDataScope(map[string]interface{}{})
+	tx := NewTransactionDataScope(parent)
+	tx.Set("key", "value")
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("value should be buffered until commit")
+	}
+	if err = tx.(*TransactionDataScope).Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "value" {
+		t.Errorf("expected committed value and take %v", value)
+	}
+}
+
+func TestScopeCloseCommit(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+	)
+	t.Parallel()
+	parent := NewDataScope(map[string]interface{}{})
+	scp := NewScope(Params{
+		DataScope: NewTransactionDataScope(parent),
+	})
+	scp.Set("key", "value")
+	// Close returns the context canceled error of the killed scope
+	scp.Close()
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "value" {
+		t.Errorf("expected committed value and take %v", value)
+	}
+}
+
+func TestScopeCloseRollback(t *testing.T) {
+	var (
+		err       error
+		value     interface{}
+		restored  interface{}
+		parent    = NewDataScope(map[string]interface{}{})
+		scp       app.Scope
+		txScope   app.DataScope
+		committed bool
+	)
+	t.Parallel()
+	txScope = NewTransactionDataScope(parent)
+	scp = NewScope(Params{
+		DataScope: txScope,
+	})
+	scp.On(app.CommitEvent, func(interface{}) error {
+		committed = true
+		return nil
+	})
+	scp.On(app.RollbackEvent, func(interface{}) (err error) {
+		restored, err = txScope.Get("key")
+		return err
+	})
+	scp.Set("key", "value")
+	scp.AppendError(goaterr.Errorf("some error"))
+	if err = scp.Close(); err == nil {
+		t.Errorf("expected error")
+	}
+	if committed {
+		t.Errorf("CommitEvent shouldn't be triggered")
+	}
+	if restored != nil {
+		t.Errorf("data should be restored before RollbackEvent and take %v", restored)
+	}
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("rollback should discard changes and take %v", value)
+	}
+}
+
+func TestChildScopeDataIsNotTransactionalByDefault(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+	)
+	t.Parallel()
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{})
+	child.Set("key", "value")
+	if value, err = child.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "value" {
+		t.Errorf("expected value visible before close and take %v", value)
+	}
+	if _, ok := child.(*ChildScope).DataScope.(dataTransaction); ok {
+		t.Errorf("default child data scope should not be transactional")
+	}
+	child.Close()
+}
+
+func TestChildScopeTransactionDataScope(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+	)
+	t.Parallel()
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{
+		DataScope: NewTransactionDataScope(parent),
+	})
+	child.Set("key", "value")
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("value should be buffered until close")
+	}
+	if err = child.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != "value" {
+		t.Errorf("expected committed value and take %v", value)
+	}
+}
+
+func TestChildScopeTransactionDataScopeRollback(t *testing.T) {
+	var (
+		err   error
+		value interface{}
+	)
+	t.Parallel()
+	parent := NewScope(Params{})
+	child := NewChildScope(parent, ChildParams{
+		DataScope: NewTransactionDataScope(parent),
+	})
+	child.Set("key", "value")
+	child.AppendError(goaterr.Errorf("some error"))
+	if err = child.Close(); err == nil {
+		t.Errorf("expected error")
+	}
+	if value, err = parent.Get("key"); err != nil {
+		t.Error(err)
+		return
+	}
+	if value != nil {
+		t.Errorf("rollback should discard changes and take %v", value)
+	}
+}
@@ -0,0 +1,102 @@
+package scope
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goatcms/goatcore/app"
+)
+
+var lastWatcherID uint64
+
+// dataWatcher is a callback connected to data scope changes
+type dataWatcher struct {
+	id       app.WatcherID
+	pattern  string
+	callback app.DataWatchCallback
+	unwatch  func()
+}
+
+// dataChange is a committed data scope change
+type dataChange struct {
+	key      string
+	oldValue interface{}
+	newValue interface{}
+}
+
+// dataWatchers is a list of data scope watchers. A zero value is ready to use.
+type dataWatchers struct {
+	list []dataWatcher
+	mu   sync.RWMutex
+}
+
+// add connect the callback and return its ID. The unwatch function (optional) is run on remove.
+func (dw *dataWatchers) add(pattern string, callback app.DataWatchCallback, unwatch func()) app.WatcherID {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	watcher := dataWatcher{
+		id:       app.WatcherID(atomic.AddUint64(&lastWatcherID, 1)),
+		pattern:  pattern,
+		callback: callback,
+		unwatch:  unwatch,
+	}
+	dw.list = append(dw.list, watcher)
+	return watcher.id
+}
+
+// remove disconnect the callback
+func (dw *dataWatchers) remove(id app.WatcherID) {
+	var unwatch func()
+	dw.mu.Lock()
+	for i, watcher := range dw.list {
+		if watcher.id == id {
+			unwatch = watcher.unwatch
+			dw.list = append(dw.list[:i:i], dw.list[i+1:]...)
+			break
+		}
+	}
+	dw.mu.Unlock()
+	if unwatch != nil {
+		unwatch()
+	}
+}
+
+// clear disconnect all callbacks
+func (dw *dataWatchers) clear() {
+	dw.mu.Lock()
+	list := dw.list
+	dw.list = nil
+	dw.mu.Unlock()
+	for _, watcher := range list {
+		if watcher.unwatch != nil {
+			watcher.unwatch()
+		}
+	}
+}
+
+// notify run matched callbacks for the changes. It must be called without data lock
+// so callbacks can read the data scope.
+func (dw *dataWatchers) notify(changes ...dataChange) {
+	if len(changes) == 0 {
+		return
+	}
+	dw.mu.RLock()
+	list := dw.list
+	dw.mu.RUnlock()
+	for _, change := range changes {
+		for _, watcher := range list {
+			if matchWatchPattern(watcher.pattern, change.key) {
+				watcher.callback(change.key, change.oldValue, change.newValue)
+			}
+		}
+	}
+}
+
+// matchWatchPattern return true if the key is the pattern or the pattern is a prefix (ends with '*') of the key
+func matchWatchPattern(pattern, key string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(key, pattern[:len(pattern)-1])
+	}
+	return pattern == key
+}
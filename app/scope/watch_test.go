@@ -0,0 +1,266 @@
+package scope
+
+import (
+	"testing"
+)
+
+type watchRecord struct {
+	key      string
+	oldValue interface{}
+	newValue interface{}
+}
+
+type watchRecorder struct {
+	records []watchRecord
+}
+
+func (recorder *watchRecorder) callback(key string, oldValue, newValue interface{}) {
+	recorder.records = append(recorder.records, watchRecord{key, oldValue, newValue})
+}
+
+func TestDataScopeWatch(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		ds       = NewDataScope(map[string]interface{}{
+			"key": "old",
+		})
+	)
+	t.Parallel()
+	id := ds.Watch("key", recorder.callback)
+	if err = ds.Set("key", "new"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = ds.Set("other", "value"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 || recorder.records[0] != (watchRecord{"key", "old", "new"}) {
+		t.Errorf("expected one key change and take %v", recorder.records)
+		return
+	}
+	ds.Unwatch(id)
+	if err = ds.Set("key", "next"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 {
+		t.Errorf("unwatched callback shouldn't be called")
+	}
+}
+
+func TestDataScopeWatchPrefix(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		ds       = NewDataScope(map[string]interface{}{})
+	)
+	t.Parallel()
+	ds.Watch("pipeline.*", recorder.callback)
+	if err = ds.Set("pipeline.status", "run"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = ds.Set("pipelines", "value"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 || recorder.records[0] != (watchRecord{"pipeline.status", nil, "run"}) {
+		t.Errorf("expected one prefix change and take %v", recorder.records)
+	}
+}
+
+func TestDataLockerWatchAfterCommit(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		ds       = NewDataScope(map[string]interface{}{
+			"key": "old",
+		})
+	)
+	t.Parallel()
+	ds.Watch("key", func(key string, oldValue, newValue interface{}) {
+		// the scope is unlocked when watchers are called
+		value, _ := ds.Get(key)
+		recorder.callback(key, oldValue, value)
+	})
+	locker := ds.LockData()
+	if err = locker.Set("key", "rollback"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = locker.Rollback(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 0 {
+		t.Errorf("rollback shouldn't notify watchers and take %v", recorder.records)
+		return
+	}
+	locker = ds.LockData()
+	nested := locker.LockData()
+	if err = nested.Set("key", "new"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = nested.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 0 {
+		t.Errorf("nested locker commit shouldn't notify watchers before the locker commit")
+		return
+	}
+	if err = locker.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 || recorder.records[0] != (watchRecord{"key", "old", "new"}) {
+		t.Errorf("expected one committed change and take %v", recorder.records)
+	}
+}
+
+func TestChildDataScopeWatch(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		parent   = NewDataScope(map[string]interface{}{
+			"key": "parent",
+		})
+		child = NewChildDataScope(parent, map[string]interface{}{})
+	)
+	t.Parallel()
+	id := child.Watch("*", recorder.callback)
+	if err = child.Set("key", "child"); err != nil {
+		t.Error(err)
+		return
+	}
+	locker := child.LockData()
+	if err = locker.Set("other", "child"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = locker.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = parent.Set("parentkey", "parent"); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []watchRecord{
+		{"key", "parent", "child"},
+		{"other", nil, "child"},
+		{"parentkey", nil, "parent"},
+	}
+	if len(recorder.records) != len(expected) {
+		t.Errorf("expected %v and take %v", expected, recorder.records)
+		return
+	}
+	for i, record := range expected {
+		if recorder.records[i] != record {
+			t.Errorf("expected %v and take %v", record, recorder.records[i])
+		}
+	}
+	child.Unwatch(id)
+	if err = parent.Set("parentkey", "next"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != len(expected) {
+		t.Errorf("unwatch should disconnect the callback from the parent")
+	}
+}
+
+func TestTransactionDataScopeWatch(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		parent   = NewDataScope(map[string]interface{}{})
+		tx       = NewTransactionDataScope(parent)
+	)
+	t.Parallel()
+	tx.Watch("key", recorder.callback)
+	if err = tx.Set("key", "value"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 0 {
+		t.Errorf("uncommitted change shouldn't notify watchers")
+		return
+	}
+	if err = tx.(dataTransaction).Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 || recorder.records[0] != (watchRecord{"key", nil, "value"}) {
+		t.Errorf("expected one committed change and take %v", recorder.records)
+	}
+}
+
+func TestChildDataScopeWatchSkipParentChangesOfOwnKeys(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		parent   = NewDataScope(map[string]interface{}{})
+		child    = NewChildDataScope(parent, map[string]interface{}{
+			"initial": "child",
+		})
+	)
+	t.Parallel()
+	child.Watch("*", recorder.callback)
+	if err = child.Set("key", "child"); err != nil {
+		t.Error(err)
+		return
+	}
+	locker := child.LockData()
+	if err = locker.Set("locked", "child"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = locker.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+	recorder.records = nil
+	for _, key := range []string{"initial", "key", "locked", "parentkey"} {
+		if err = parent.Set(key, "parent"); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(recorder.records) != 1 || recorder.records[0] != (watchRecord{"parentkey", nil, "parent"}) {
+		t.Errorf("expected parentkey change only and take %v", recorder.records)
+	}
+}
+
+func TestChildScopeCloseUnwatchParent(t *testing.T) {
+	var (
+		err      error
+		recorder watchRecorder
+		parent   = NewScope(Params{})
+		child    = NewChildScope(parent, ChildParams{})
+	)
+	t.Parallel()
+	child.Watch("*", recorder.callback)
+	if err = parent.Set("key", "value"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 {
+		t.Errorf("expected parent change and take %v", recorder.records)
+		return
+	}
+	if err = child.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = parent.Set("key", "next"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(recorder.records) != 1 {
+		t.Errorf("closed child scope callbacks shouldn't be called and take %v", recorder.records)
+	}
+}
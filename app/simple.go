@@ -6,5 +6,14 @@ type TypeConverter func(interface{}) (interface{}, error)
 // EventCallback is a callback function with data
 type EventCallback func(interface{}) error
 
+// ListenerID identify a connected event callback (it is used to remove the callback by EventScope.Off)
+type ListenerID uint64
+
+// DataWatchCallback is a callback function for a committed data scope change
+type DataWatchCallback func(key string, oldValue, newValue interface{})
+
+// WatcherID identify a watch callback (it is used to remove the callback by DataScope.Unwatch)
+type WatcherID uint64
+
 // Callback is a callback function
 type Callback func() error
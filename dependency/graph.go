@@ -0,0 +1,148 @@
+package dependency
+
+import (
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// InstanceSource is a source of dependency defined by Set
+	InstanceSource = "instance"
+	// DefaultInstanceSource is a source of dependency defined by SetDefault
+	DefaultInstanceSource = "default instance"
+	// FactorySource is a source of dependency defined by a factory
+	FactorySource = "factory"
+	// DefaultFactorySource is a source of dependency defined by AddDefaultFactory
+	DefaultFactorySource = "default factory"
+)
+
+// GraphNode describe a registered dependency
+type GraphNode struct {
+	Name     string
+	Source   string
+	Lifetime Lifetime
+	// Overridden is true if a default definition was replaced
+	Overridden bool
+	// Used is true if the dependency was requested
+	Used bool
+	// Decorators is a number of decorators wrapping the dependency
+	Decorators int
+	// Dependencies are keys requested by the dependency factory
+	Dependencies []string
+}
+
+// Graph describe dependencies and relations between them
+type Graph struct {
+	// Nodes are sorted by name
+	Nodes []GraphNode
+	// Roots are keys requested directly (not by a factory)
+	Roots []string
+}
+
+// String return lifetime name
+func (lifetime Lifetime) String() string {
+	switch lifetime {
+	case Transient:
+		return "transient"
+	case Scoped:
+		return "scoped"
+	}
+	return "singleton"
+}
+
+// Node return a node by name
+func (graph Graph) Node(name string) (node GraphNode, ok bool) {
+	i := sort.Search(len(graph.Nodes), func(i int) bool {
+		return graph.Nodes[i].Name >= name
+	})
+	if i < len(graph.Nodes) && graph.Nodes[i].Name == name {
+		return graph.Nodes[i], true
+	}
+	return node, false
+}
+
+// Unused return names of registered and never requested dependencies
+func (graph Graph) Unused() (names []string) {
+	for _, node := range graph.Nodes {
+		if !node.Used {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+// WriteTree write the graph as a text tree
+func (graph Graph) WriteTree(w io.Writer) (err error) {
+	var (
+		sb      strings.Builder
+		printed = map[string]bool{}
+	)
+	for _, name := range graph.Roots {
+		graph.writeTreeNode(&sb, name, "", printed)
+	}
+	if unused := graph.Unused(); len(unused) != 0 {
+		sb.WriteString("\nUnused:\n")
+		for _, name := range unused {
+			sb.WriteString("  " + name + " " + graph.describe(name) + "\n")
+		}
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (graph Graph) writeTreeNode(sb *strings.Builder, name, indent string, printed map[string]bool) {
+	node, _ := graph.Node(name)
+	if printed[name] && len(node.Dependencies) != 0 {
+		sb.WriteString(indent + name + " (see above)\n")
+		return
+	}
+	printed[name] = true
+	sb.WriteString(indent + name + " " + graph.describe(name) + "\n")
+	for _, dep := range node.Dependencies {
+		graph.writeTreeNode(sb, dep, indent+"  ", printed)
+	}
+}
+
+func (graph Graph) describe(name string) string {
+	node, ok := graph.Node(name)
+	if !ok {
+		return "[undefined]"
+	}
+	desc := "[" + node.Source + ", " + node.Lifetime.String()
+	if node.Overridden {
+		desc += ", overridden"
+	}
+	if node.Decorators != 0 {
+		desc += ", decorated"
+	}
+	return desc + "]"
+}
+
+// WriteDOT write the graph in Graphviz DOT format
+func (graph Graph) WriteDOT(w io.Writer) (err error) {
+	var sb strings.Builder
+	sb.WriteString("digraph dependencies {\n")
+	for _, node := range graph.Nodes {
+		attrs := []string{`label="` + dotEscape(node.Name) + `\n` + dotEscape(graph.describe(node.Name)) + `"`}
+		if !node.Used {
+			attrs = append(attrs, "style=dashed")
+		}
+		if node.Overridden {
+			attrs = append(attrs, "color=blue")
+		}
+		sb.WriteString("  \"" + dotEscape(node.Name) + "\" [" + strings.Join(attrs, ", ") + "];\n")
+	}
+	for _, node := range graph.Nodes {
+		for _, dep := range node.Dependencies {
+			sb.WriteString("  \"" + dotEscape(node.Name) + "\" -> \"" + dotEscape(dep) + "\";\n")
+		}
+	}
+	sb.WriteString("}\n")
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -1,18 +1,62 @@
 package dependency
 
+import "reflect"
+
 // Factory represent a builder of a dependency instance
 type Factory func(Provider) (interface{}, error)
 
+// Decorator wrap a dependency instance (built by a factory) and return the wrapped instance
+type Decorator func(Provider, interface{}) (interface{}, error)
+
+// Lifetime describe how long a dependency instance lives
+type Lifetime int
+
+const (
+	// Singleton instance is created once per provider (default)
+	Singleton Lifetime = iota
+	// Transient instance is created for every Get
+	Transient
+	// Scoped instance is created once per ScopedProvider and disposed with it
+	Scoped
+)
+
 // Provider distribute dependencies
 type Provider interface {
 	Injector
 	AddInjectors([]Injector) error
 	Get(string) (interface{}, error)
+	Resolve(reflect.Type) (interface{}, error)
 	Set(string, interface{}) error
 	Keys() ([]string, error)
 	SetDefault(string, interface{}) error
 	AddFactory(string, Factory) error
 	AddDefaultFactory(string, Factory) error
+	AddTransientFactory(string, Factory) error
+	AddScopedFactory(string, Factory) error
+	AddDecorator(string, Decorator) error
+	// DeclareType declare a dependency type for type-based injection (Resolve). Factories are
+	// not called to check types so a factory dependency is resolved by its declared type only.
+	DeclareType(string, reflect.Type) error
+	CreateScope() ScopedProvider
+	// CreateChild create a child provider. The child falls back to the provider for undefined
+	// dependencies and its own definitions (and overrides) are not visible to the provider.
+	CreateChild() Provider
+	Graph() Graph
+	// Dispose release instances created by factories (Disposable and io.Closer instances
+	// are disposed in reverse creation order)
+	Dispose() error
+}
+
+// ScopedProvider distribute dependencies for a single scope (like a command context
+// or a pipeline task). It creates scoped instances once and shares singletons with a parent provider.
+// Dispose release scoped instances only.
+type ScopedProvider interface {
+	Provider
+}
+
+// Disposable is an instance which release resources when a provider is disposed
+type Disposable interface {
+	Dispose() error
 }
 
 // Injector provide interface to inject data
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// waitGraphMU guard wait-for relations between instances under construction (of all providers)
+var waitGraphMU sync.Mutex
+
+// callstack is a dependency call chain of a single goroutine. The owner is the innermost
+// instance under construction by the chain (it is nil for a top level call).
+type callstack struct {
+	names []string
+	owner *pendingInstance
+}
+
+// pendingInstance is an instance under construction. The waitsFor is an instance the builder waits for.
+type pendingInstance struct {
+	name     string
+	done     chan struct{}
+	instance interface{}
+	err      error
+	waitsFor *pendingInstance
+}
+
+// call is a provider view passed to a factory. It carries the factory callstack
+// to detect cyclic dependencies per call chain (instead of a state shared between goroutines).
+type call struct {
+	dependency.Provider
+	callstack callstack
+	get       func(callstack, string) (interface{}, error)
+	resolve   func(callstack, reflect.Type) (interface{}, error)
+	tagname   string
+	injectors []dependency.Injector
+}
+
+func newCall(d *Provider, stack callstack, name string, get func(callstack, string) (interface{}, error), resolve func(callstack, reflect.Type) (interface{}, error)) *call {
+	return &call{
+		Provider: d,
+		callstack: callstack{
+			names: stack.with(name),
+			owner: stack.owner,
+		},
+		get:       get,
+		resolve:   resolve,
+		tagname:   d.tagname,
+		injectors: d.injectors,
+	}
+}
+
+// Get return instance by name
+func (c *call) Get(name string) (interface{}, error) {
+	return c.get(c.callstack, name)
+}
+
+// Resolve return the single dependency assignable to the type
+func (c *call) Resolve(t reflect.Type) (interface{}, error) {
+	return c.resolve(c.callstack, t)
+}
+
+// InjectTo inject dependencies to object
+func (c *call) InjectTo(obj interface{}) error {
+	return inject(obj, c.tagname, c.Get, c.Resolve, c.injectors)
+}
+
+func (stack callstack) caller() string {
+	if len(stack.names) == 0 {
+		return ""
+	}
+	return stack.names[len(stack.names)-1]
+}
+
+func (stack callstack) has(name string) bool {
+	for _, v := range stack.names {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// with return a copy of the callstack names with the name at the end
+func (stack callstack) with(name string) []string {
+	return append(append(make([]string, 0, len(stack.names)+1), stack.names...), name)
+}
+
+func (stack callstack) cycleError(name string) error {
+	return goaterr.Errorf("%s is cyclic dependency (dependency callstack: %v)", name, stack.with(name))
+}
+
+// build run the builder as the owner of the pending instance. The current owner waits for
+// the pending instance until the builder ends.
+func (stack callstack) build(pending *pendingInstance, builder func(callstack)) {
+	release := stack.dependOn(pending)
+	builder(callstack{
+		names: stack.names,
+		owner: pending,
+	})
+	release()
+}
+
+// wait for an instance under construction by other call chain. It returns a cycle error if
+// the instance waits (directly or not) for the callstack owner, so concurrent cyclic
+// dependencies don't block forever.
+func (stack callstack) wait(pending *pendingInstance) (interface{}, error) {
+	if stack.owner != nil {
+		waitGraphMU.Lock()
+		for next := pending; next != nil; next = next.waitsFor {
+			if next == stack.owner {
+				waitGraphMU.Unlock()
+				return nil, goaterr.Errorf("%s is cyclic dependency (concurrent dependency callstack: %v)", pending.name, stack.with(pending.name))
+			}
+		}
+		stack.owner.waitsFor = pending
+		waitGraphMU.Unlock()
+		defer func() {
+			waitGraphMU.Lock()
+			stack.owner.waitsFor = nil
+			waitGraphMU.Unlock()
+		}()
+	}
+	<-pending.done
+	return pending.instance, pending.err
+}
+
+// dependOn mark the owner waits for the pending instance. It returns a function which removes the mark.
+func (stack callstack) dependOn(pending *pendingInstance) (release func()) {
+	if stack.owner == nil {
+		return func() {}
+	}
+	waitGraphMU.Lock()
+	stack.owner.waitsFor = pending
+	waitGraphMU.Unlock()
+	return func() {
+		waitGraphMU.Lock()
+		stack.owner.waitsFor = nil
+		waitGraphMU.Unlock()
+	}
+}
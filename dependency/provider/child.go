@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"github.com/goatcms/goatcore/dependency"
+)
+
+// newChildProvider create a provider which gets undefined dependencies from the parent.
+// The child can define (or override) dependencies locally and they are not visible to the parent.
+// Dispose release instances created by the child only.
+func newChildProvider(parent dependency.Provider, tagname string, injectors []dependency.Injector) *Provider {
+	child := NewProvider(tagname).(*Provider)
+	child.parent = parent
+	child.injectors = append(child.injectors, injectors...)
+	return child
+}
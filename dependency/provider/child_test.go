@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+func TestChildProviderFallbackToParent(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	child := dp.CreateChild()
+	fromChild, err := child.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromParent, err := dp.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if fromChild != fromParent {
+		t.Errorf("child should share parent instances")
+	}
+	obj := &SimpleObject{}
+	if err = child.InjectTo(obj); err != nil {
+		t.Error(err)
+		return
+	}
+	if obj.Instance != fromParent {
+		t.Errorf("child should inject parent instances")
+	}
+}
+
+func TestChildProviderOverride(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	child := dp.CreateChild()
+	if err := child.AddFactory(MyDepName, func(dp dependency.Provider) (interface{}, error) {
+		return &MyDep{value: 1}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](child, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := child.AddFactory("local", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	childIns, err := child.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if childIns.(*MyDep).Get() != 1 {
+		t.Errorf("child should use the local definition")
+	}
+	parentIns, err := dp.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if parentIns.(*MyDep).Get() != 0 {
+		t.Errorf("child override shouldn't leak to the parent")
+	}
+	if _, err = dp.Get("local"); err == nil {
+		t.Errorf("child definition shouldn't be visible for the parent")
+	}
+	keys, err := child.Keys()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(keys) != 2 {
+		t.Errorf("expected 2 keys (parent and local keys without duplicates) and take %v", keys)
+	}
+	if _, err = dependency.Resolve[MyDepInterface](child); err != nil {
+		t.Errorf("overridden dependency shouldn't be ambiguous: %v", err)
+	}
+}
+
+func TestChildProviderDispose(t *testing.T) {
+	t.Parallel()
+	var closed []string
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("parent", func(dp dependency.Provider) (interface{}, error) {
+		return &closeRecorder{name: "parent", closed: &closed}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	child := dp.CreateChild()
+	if err := child.AddFactory("child", func(dp dependency.Provider) (interface{}, error) {
+		if _, err := dp.Get("parent"); err != nil {
+			return nil, err
+		}
+		return &closeRecorder{name: "child", closed: &closed}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := child.Get("child"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := child.Dispose(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(closed) != 1 || closed[0] != "child" {
+		t.Errorf("child should dispose local instances only and take %v", closed)
+	}
+	if err := dp.Dispose(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(closed) != 2 || closed[1] != "parent" {
+		t.Errorf("expected parent instance disposed and take %v", closed)
+	}
+}
+
+func TestScopedProviderChild(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateScope()
+	child := scope.CreateChild()
+	fromChild, err := child.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromScope, err := scope.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if fromChild != fromScope {
+		t.Errorf("child of a scoped provider should share scoped instances")
+	}
+}
+
+func TestChildProviderPassCallstackToParent(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("parentDep", func(dp dependency.Provider) (interface{}, error) {
+		return nil, goaterr.Errorf("parentDep error")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	child := dp.CreateChild()
+	if err := child.AddFactory("childDep", func(dp dependency.Provider) (interface{}, error) {
+		return dp.Get("parentDep")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	_, err := child.Get("childDep")
+	if err == nil {
+		t.Errorf("expected error")
+		return
+	}
+	if !strings.Contains(err.Error(), "[childDep parentDep]") {
+		t.Errorf("expected the child callstack in the parent error and take %v", err)
+	}
+	graph := dp.Graph()
+	node, _ := graph.Node("parentDep")
+	if !node.Used {
+		t.Errorf("expected used parentDep node")
+	}
+	if len(graph.Roots) != 0 {
+		t.Errorf("parentDep is requested by childDep (it is not a root) and take roots %v", graph.Roots)
+	}
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goatcms/goatcore/dependency"
+)
+
+func TestConcurrentGetCallFactoryOnce(t *testing.T) {
+	t.Parallel()
+	var (
+		calls int32
+		wg    sync.WaitGroup
+		dp    = NewProvider(TagName)
+		errs  = make(chan error, 20)
+		insts = make(chan interface{}, 20)
+	)
+	if err := dp.AddDefaultFactory(MyDepName, func(dp dependency.Provider) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &MyDep{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj := &SimpleObject{}
+			if err := dp.InjectTo(obj); err != nil {
+				errs <- err
+				return
+			}
+			insts <- obj.Instance
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	close(insts)
+	for err := range errs {
+		t.Error(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected one factory call and take %d", calls)
+	}
+	var first interface{}
+	for ins := range insts {
+		if first == nil {
+			first = ins
+		}
+		if ins != first {
+			t.Errorf("expected the same instance for all goroutines")
+		}
+	}
+}
+
+func TestConcurrentCycleDetectionIsPerCallChain(t *testing.T) {
+	t.Parallel()
+	var (
+		wg   sync.WaitGroup
+		dp   = NewProvider(TagName)
+		errs = make(chan error, 20)
+	)
+	if err := dp.AddDefaultFactory("one", func(dp dependency.Provider) (interface{}, error) {
+		time.Sleep(5 * time.Millisecond)
+		return NewOne(), nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("two", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddTransientFactory("three", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := dp.Get("two")
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := dp.Get("three")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestConcurrentScopedProvider(t *testing.T) {
+	t.Parallel()
+	var (
+		calls int32
+		wg    sync.WaitGroup
+		dp    = NewProvider(TagName)
+	)
+	if err := dp.AddScopedFactory(MyDepName, func(dp dependency.Provider) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(5 * time.Millisecond)
+		return &MyDep{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateScope()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := scope.Get(MyDepName); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected one factory call per scope and take %d", calls)
+	}
+}
+
+func TestFailedFactoryIsNotCached(t *testing.T) {
+	t.Parallel()
+	var (
+		calls int32
+		dp    = NewProvider(TagName)
+	)
+	if err := dp.AddDefaultFactory(MyCircleDepName, MyCircleDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory(MyDepName, func(dp dependency.Provider) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return dp.Get(MyCircleDepName)
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		_, err := dp.Get(MyDepName)
+		if err == nil || !strings.Contains(err.Error(), "MyCircleDep is cyclic dependency") {
+			t.Errorf("expected cyclic dependency error and take %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("failed factory should be called again and it was called %d times", calls)
+	}
+}
+
+type cyclicA struct{}
+
+type cyclicC struct{}
+
+func TestConcurrentCyclicDependencyReturnError(t *testing.T) {
+	t.Parallel()
+	var (
+		wg   sync.WaitGroup
+		dp   = NewProvider(TagName)
+		errs = make(chan error, 2)
+		done = make(chan struct{})
+	)
+	if err := dependency.DeclareType[*cyclicA](dp, "a"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[*cyclicC](dp, "c"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("a", func(dp dependency.Provider) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := dp.Resolve(reflect.TypeOf(&cyclicC{})); err != nil {
+			return nil, err
+		}
+		return &cyclicA{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("c", func(dp dependency.Provider) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := dp.Resolve(reflect.TypeOf(&cyclicA{})); err != nil {
+			return nil, err
+		}
+		return &cyclicC{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, name := range []string{"a", "c"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := dp.Get(name)
+			errs <- err
+		}(name)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("concurrent cyclic dependency is blocked")
+		return
+	}
+	close(errs)
+	for err := range errs {
+		if err == nil {
+			t.Errorf("expected cyclic dependency error")
+			continue
+		}
+		if !strings.Contains(err.Error(), "cyclic dependency") {
+			t.Errorf("expected cyclic dependency error and take %v", err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+type valueDecorator struct {
+	base  TestInterface
+	delta int
+}
+
+func (d valueDecorator) Value() int {
+	return d.base.Value() + d.delta
+}
+
+func (d valueDecorator) Test() bool {
+	return d.base.Test()
+}
+
+func addValueDecorator(delta int) dependency.Decorator {
+	return func(dp dependency.Provider, instance interface{}) (interface{}, error) {
+		return valueDecorator{
+			base:  instance.(TestInterface),
+			delta: delta,
+		}, nil
+	}
+}
+
+func TestDecorators(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("one", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDecorator("one", addValueDecorator(10)); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDecorator("one", func(dp dependency.Provider, instance interface{}) (interface{}, error) {
+		if instance.(TestInterface).Value() != 11 {
+			return nil, goaterr.Errorf("decorators should be applied in registration order")
+		}
+		return addValueDecorator(100)(dp, instance)
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	ins, err := dp.Get("one")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ins.(TestInterface).Value() != 111 {
+		t.Errorf("expected 111 and take %d", ins.(TestInterface).Value())
+	}
+	node, _ := dp.Graph().Node("one")
+	if node.Decorators != 2 {
+		t.Errorf("expected 2 decorators in graph and take %d", node.Decorators)
+	}
+	if err = dp.AddDecorator("one", addValueDecorator(1)); err == nil {
+		t.Errorf("expected error for decorator added after first get")
+	}
+}
+
+func TestDecoratorsForScopedAndTransient(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddTransientFactory("transient", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddScopedFactory("scoped", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDecorator("transient", addValueDecorator(1)); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDecorator("scoped", addValueDecorator(2)); err != nil {
+		t.Error(err)
+		return
+	}
+	ins, err := dp.Get("transient")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ins.(TestInterface).Value() != 2 {
+		t.Errorf("expected decorated transient dependency")
+	}
+	if ins, err = dp.CreateScope().Get("scoped"); err != nil {
+		t.Error(err)
+		return
+	}
+	if ins.(TestInterface).Value() != 3 {
+		t.Errorf("expected decorated scoped dependency")
+	}
+}
+
+func TestDecoratorError(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("one", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDecorator("one", func(dp dependency.Provider, instance interface{}) (interface{}, error) {
+		return dp.Get("one")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("one"); err == nil {
+		t.Errorf("expected cyclic dependency error from decorator")
+	}
+}
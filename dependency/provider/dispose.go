@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// disposeInstances dispose instances in reverse order of names.
+// An instance registered under many names is disposed once.
+func disposeInstances(names []string, instances map[string]interface{}) error {
+	var (
+		errs     []error
+		disposed = map[interface{}]bool{}
+	)
+	for i := len(names) - 1; i >= 0; i-- {
+		instance := instances[names[i]]
+		if instance == nil {
+			continue
+		}
+		if reflect.TypeOf(instance).Comparable() {
+			if disposed[instance] {
+				continue
+			}
+			disposed[instance] = true
+		}
+		if err := disposeInstance(instance); err != nil {
+			errs = append(errs, goaterr.Wrapf("goatcore/dependency/provider: dispose %s error", err, names[i]))
+		}
+	}
+	return goaterr.ToError(errs)
+}
+
+// disposeInstance call Dispose (for dependency.Disposable) or Close (for io.Closer)
+func disposeInstance(instance interface{}) error {
+	switch v := instance.(type) {
+	case dependency.Disposable:
+		return v.Dispose()
+	case io.Closer:
+		return v.Close()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+)
+
+type disposeRecorder struct {
+	name     string
+	disposed *[]string
+}
+
+func (d *disposeRecorder) Dispose() error {
+	*d.disposed = append(*d.disposed, d.name)
+	return nil
+}
+
+func TestProviderDispose(t *testing.T) {
+	t.Parallel()
+	var (
+		disposed []string
+		dp       = NewProvider(TagName)
+		shared   = &disposeRecorder{name: "shared", disposed: &disposed}
+	)
+	if err := dp.AddDefaultFactory("first", func(dp dependency.Provider) (interface{}, error) {
+		return shared, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("alias", func(dp dependency.Provider) (interface{}, error) {
+		return dp.Get("first")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("second", func(dp dependency.Provider) (interface{}, error) {
+		if _, err := dp.Get("alias"); err != nil {
+			return nil, err
+		}
+		return &closeRecorder{name: "second", closed: &disposed}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.Set("instance", &disposeRecorder{name: "instance", disposed: &disposed}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("second"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("instance"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.Dispose(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(disposed) != 2 || disposed[0] != "second" || disposed[1] != "shared" {
+		t.Errorf("expected [second shared] and take %v", disposed)
+	}
+	if err := dp.Dispose(); err != nil || len(disposed) != 2 {
+		t.Errorf("dispose should be idempotent (%v, %v)", err, disposed)
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/goatcms/goatcore/dependency"
+)
+
+// graphRecorder collect dependencies definitions and relations between them
+type graphRecorder struct {
+	sources    map[string]string
+	overridden map[string]bool
+	used       map[string]bool
+	edges      map[string][]string
+	roots      []string
+}
+
+func newGraphRecorder() *graphRecorder {
+	return &graphRecorder{
+		sources:    map[string]string{},
+		overridden: map[string]bool{},
+		used:       map[string]bool{},
+		edges:      map[string][]string{},
+		roots:      []string{},
+	}
+}
+
+// define record a dependency definition. A default definition doesn't replace a main definition.
+func (gr *graphRecorder) define(name, source string) {
+	current, exist := gr.sources[name]
+	if !exist {
+		gr.sources[name] = source
+		return
+	}
+	if isDefaultSource(current) != isDefaultSource(source) {
+		gr.overridden[name] = true
+	}
+	if !isDefaultSource(source) {
+		gr.sources[name] = source
+	}
+}
+
+// record a request for name dependency. The from is a requesting dependency or empty for direct requests.
+func (gr *graphRecorder) record(from, name string) {
+	gr.used[name] = true
+	if from == "" {
+		gr.roots = appendUnique(gr.roots, name)
+		return
+	}
+	gr.edges[from] = appendUnique(gr.edges[from], name)
+}
+
+func (gr *graphRecorder) graph(keys []string, lifetimes map[string]dependency.Lifetime, decorators map[string][]dependency.Decorator) (graph dependency.Graph) {
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	graph.Nodes = make([]dependency.GraphNode, len(sorted))
+	for i, name := range sorted {
+		source, ok := gr.sources[name]
+		if !ok {
+			source = dependency.FactorySource
+		}
+		graph.Nodes[i] = dependency.GraphNode{
+			Name:         name,
+			Source:       source,
+			Lifetime:     lifetimes[name],
+			Overridden:   gr.overridden[name],
+			Used:         gr.used[name],
+			Decorators:   len(decorators[name]),
+			Dependencies: append([]string{}, gr.edges[name]...),
+		}
+	}
+	graph.Roots = append([]string{}, gr.roots...)
+	return graph
+}
+
+func isDefaultSource(source string) bool {
+	return source == dependency.DefaultInstanceSource || source == dependency.DefaultFactorySource
+}
+
+func appendUnique(arr []string, s string) []string {
+	for _, v := range arr {
+		if v == s {
+			return arr
+		}
+	}
+	return append(arr, s)
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("one", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddFactory("one", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("two", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.SetDefault("unused", 1); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("two"); err != nil {
+		t.Error(err)
+		return
+	}
+	graph := dp.Graph()
+	one, ok := graph.Node("one")
+	if !ok {
+		t.Errorf("expected one node")
+		return
+	}
+	if one.Source != dependency.FactorySource || !one.Overridden || !one.Used {
+		t.Errorf("incorrect one node: %+v", one)
+	}
+	two, _ := graph.Node("two")
+	if len(two.Dependencies) != 1 || two.Dependencies[0] != "one" {
+		t.Errorf("expected two -> one relation and take %v", two.Dependencies)
+	}
+	if len(graph.Roots) != 1 || graph.Roots[0] != "two" {
+		t.Errorf("expected two as the only root and take %v", graph.Roots)
+	}
+	if unused := graph.Unused(); len(unused) != 1 || unused[0] != "unused" {
+		t.Errorf("expected unused dependency and take %v", unused)
+	}
+	buf := &bytes.Buffer{}
+	if err := graph.WriteTree(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "two [default factory, singleton]\n" +
+		"  one [factory, singleton, overridden]\n" +
+		"\nUnused:\n" +
+		"  unused [default instance, singleton]\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ntake:\n%s", expected, buf.String())
+	}
+	buf.Reset()
+	if err := graph.WriteDOT(buf); err != nil {
+		t.Error(err)
+		return
+	}
+	expected = "digraph dependencies {\n" +
+		"  \"one\" [label=\"one\\n[factory, singleton, overridden]\", color=blue];\n" +
+		"  \"two\" [label=\"two\\n[default factory, singleton]\"];\n" +
+		"  \"unused\" [label=\"unused\\n[default instance, singleton]\", style=dashed];\n" +
+		"  \"two\" -> \"one\";\n" +
+		"}\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ntake:\n%s", expected, buf.String())
+	}
+}
+
+func TestGraphRecordsFailedFactoryCallstack(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("two", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("two"); err == nil {
+		t.Errorf("expected error (one dependency is undefined)")
+		return
+	}
+	if _, err := dp.Get("two"); err == nil || strings.Contains(err.Error(), "cyclic") {
+		t.Errorf("expected missing dependency error (not a cyclic dependency error) and take %v", err)
+	}
+	two, _ := dp.Graph().Node("two")
+	if len(two.Dependencies) != 1 || two.Dependencies[0] != "one" {
+		t.Errorf("expected two -> one relation and take %v", two.Dependencies)
+	}
+}
@@ -3,21 +3,31 @@ package provider
 import (
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/goatcms/goatcore/dependency"
 	"github.com/goatcms/goatcore/varutil/goaterr"
 )
 
-// Provider is default dependency distributor
+// Provider is default dependency distributor. It is safe for concurrent use.
+// A singleton factory is called once per key (concurrent getters wait for the result).
 type Provider struct {
+	mu               sync.Mutex
 	injectors        []dependency.Injector
 	defaultFactories map[string]dependency.Factory
 	factories        map[string]dependency.Factory
 	defaultInstances map[string]interface{}
 	instances        map[string]interface{}
-	callstack        []string
+	lifetimes        map[string]dependency.Lifetime
+	decorators       map[string][]dependency.Decorator
+	types            map[string]reflect.Type
+	pending          map[string]*pendingInstance
+	created          []string
+	graph            *graphRecorder
+	parent           dependency.Provider
 	keys             []string
 	blocked          bool
+	disposed         bool
 	autoclean        bool
 	tagname          string
 }
@@ -30,7 +40,12 @@ func NewProvider(tagname string) dependency.Provider {
 		factories:        map[string]dependency.Factory{},
 		defaultInstances: map[string]interface{}{},
 		instances:        map[string]interface{}{},
-		callstack:        []string{},
+		lifetimes:        map[string]dependency.Lifetime{},
+		decorators:       map[string][]dependency.Decorator{},
+		types:            map[string]reflect.Type{},
+		pending:          map[string]*pendingInstance{},
+		created:          []string{},
+		graph:            newGraphRecorder(),
 		keys:             []string{},
 		blocked:          false,
 		autoclean:        true,
@@ -52,7 +67,12 @@ func NewStaticProvider(tagname string, factories map[string]dependency.Factory,
 		factories:        factories,
 		defaultInstances: map[string]interface{}{},
 		instances:        instances,
-		callstack:        []string{},
+		lifetimes:        map[string]dependency.Lifetime{},
+		decorators:       map[string][]dependency.Decorator{},
+		types:            map[string]reflect.Type{},
+		pending:          map[string]*pendingInstance{},
+		created:          []string{},
+		graph:            newGraphRecorder(),
 		keys:             keys,
 		blocked:          true,
 		autoclean:        false,
@@ -62,6 +82,8 @@ func NewStaticProvider(tagname string, factories map[string]dependency.Factory,
 
 // AddInjectors add new injector to dependency provider
 func (d *Provider) AddInjectors(injectors []dependency.Injector) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.blocked {
 		return goaterr.Errorf("goatcore/dependency/provider.AddInjectors: can not add new injector after got dependency")
 	}
@@ -69,13 +91,28 @@ func (d *Provider) AddInjectors(injectors []dependency.Injector) error {
 	return nil
 }
 
-// Keys return list of all defined dependencies names
+// Keys return list of all defined dependencies names (including parent dependencies for a child provider)
 func (d *Provider) Keys() ([]string, error) {
-	return d.keys, nil
+	d.mu.Lock()
+	keys := append([]string{}, d.keys...)
+	d.mu.Unlock()
+	if d.parent == nil {
+		return keys, nil
+	}
+	parentKeys, err := d.parent.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range parentKeys {
+		keys = appendUnique(keys, key)
+	}
+	return keys, nil
 }
 
 // Block prevent nev dependency definition
 func (d *Provider) Block() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.blocked == true {
 		return
 	}
@@ -94,48 +131,152 @@ func (d *Provider) Block() {
 
 // Get return instance by name
 func (d *Provider) Get(name string) (interface{}, error) {
+	return d.getFor(callstack{}, name)
+}
+
+// getFor return instance by name and record the request in the dependency graph
+func (d *Provider) getFor(stack callstack, name string) (interface{}, error) {
 	d.Block()
-	if d.isCalled(name) {
-		return nil, goaterr.Errorf("%s is cyclic dependency (dependency callstack: %v)", name, append(d.callstack, name))
+	d.mu.Lock()
+	d.graph.record(stack.caller(), name)
+	d.mu.Unlock()
+	return d.get(stack, name)
+}
+
+func (d *Provider) get(stack callstack, name string) (instance interface{}, err error) {
+	if stack.has(name) {
+		return nil, stack.cycleError(name)
 	}
+	d.mu.Lock()
 	if instance, exist := d.instances[name]; exist {
+		d.mu.Unlock()
 		return instance, nil
 	}
-	if factory, exist := d.factories[name]; exist {
-		d.callstack = append(d.callstack, name)
-		instance, err := factory(d)
-		if err != nil {
-			return nil, goaterr.Errorf("%v (dependency callstack: %v)", err, d.callstack)
-		}
-		if instance == nil {
-			return nil, goaterr.Errorf("factory for %s return nil as instance", name)
+	switch d.lifetimes[name] {
+	case dependency.Transient:
+		factory := d.factories[name]
+		d.mu.Unlock()
+		return d.build(stack, name, factory)
+	case dependency.Scoped:
+		d.mu.Unlock()
+		return nil, goaterr.Errorf("goatcore/dependency/provider: dependency %s is scoped (get it from a scoped provider)", name)
+	}
+	if pending, exist := d.pending[name]; exist {
+		d.mu.Unlock()
+		return stack.wait(pending)
+	}
+	factory, exist := d.factories[name]
+	if !exist {
+		if factory, exist = d.defaultFactories[name]; !exist {
+			d.mu.Unlock()
+			if parent, ok := d.parent.(callChain); ok {
+				return parent.getFor(stack, name)
+			}
+			if d.parent != nil {
+				return d.parent.Get(name)
+			}
+			return nil, goaterr.Errorf("goatcore/dependency/provider: dependency %s doesn't exist", name)
 		}
-		d.callstack = d.callstack[:len(d.callstack)-1]
+	}
+	pending := &pendingInstance{
+		name: name,
+		done: make(chan struct{}),
+	}
+	d.pending[name] = pending
+	d.mu.Unlock()
+	stack.build(pending, func(owner callstack) {
+		pending.instance, pending.err = d.build(owner, name, factory)
+	})
+	d.mu.Lock()
+	delete(d.pending, name)
+	if pending.err == nil {
 		d.clean(name)
-		d.instances[name] = instance
-		return instance, nil
+		d.instances[name] = pending.instance
+		d.created = append(d.created, name)
 	}
-	if factory, exist := d.defaultFactories[name]; exist {
-		d.callstack = append(d.callstack, name)
-		instance, err := factory(d)
-		if err != nil {
-			return nil, goaterr.Errorf("%v (dependency callstack: %v)", err, d.callstack)
-		}
-		if instance == nil {
-			return nil, goaterr.Errorf("default factory for %s return nil as instance", name)
-		}
-		d.callstack = d.callstack[:len(d.callstack)-1]
-		if d.autoclean {
-			delete(d.defaultFactories, name)
+	d.mu.Unlock()
+	close(pending.done)
+	return pending.instance, pending.err
+}
+
+// Graph return dependencies graph (definitions and relations recorded by Get).
+// A child provider graph contains local definitions only.
+func (d *Provider) Graph() dependency.Graph {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.graph.graph(d.keys, d.lifetimes, d.decorators)
+}
+
+// Resolve return the single dependency assignable to the type. Dependencies are matched
+// by types of instances added by Set/SetDefault and by declared types (see DeclareType).
+// Only the matched dependency is created.
+func (d *Provider) Resolve(t reflect.Type) (interface{}, error) {
+	return d.resolveFor(callstack{}, t)
+}
+
+func (d *Provider) resolveFor(stack callstack, t reflect.Type) (interface{}, error) {
+	d.Block()
+	keys, _ := d.Keys()
+	name, err := resolve(t, keys, func(name string) bool {
+		return stack.has(name) || d.lifetime(name) == dependency.Scoped
+	}, d.typeOf)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.graph.record(stack.caller(), name)
+	d.mu.Unlock()
+	return d.get(stack, name)
+}
+
+// typeOf return the dependency type without creating it. It is the type of an instance
+// added by Set/SetDefault or the declared type (never of a factory result).
+func (d *Provider) typeOf(name string) (t reflect.Type, ok bool) {
+	d.mu.Lock()
+	instance, exist := d.instances[name]
+	if exist && d.isCreated(name) {
+		// a singleton created by a factory is not matched (a result must not depend on previous Get calls)
+		exist = false
+	}
+	if !exist {
+		instance, exist = d.defaultInstances[name]
+	}
+	declared, isDeclared := d.types[name]
+	isLocal := d.hasKey(name)
+	d.mu.Unlock()
+	if exist && instance != nil {
+		return reflect.TypeOf(instance), true
+	}
+	if isDeclared {
+		return declared, true
+	}
+	if !isLocal && d.parent != nil {
+		if parent, ok := d.parent.(typeIndex); ok {
+			return parent.typeOf(name)
 		}
-		d.instances[name] = instance
-		return instance, nil
 	}
-	return nil, goaterr.Errorf("goatcore/dependency/provider: dependency %s doesn't exist", name)
+	return nil, false
+}
+
+// DeclareType declare a dependency type for type-based injection. An instance created
+// by the dependency factory must be assignable to the type.
+func (d *Provider) DeclareType(name string, t reflect.Type) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.blocked {
+		return goaterr.Errorf("goatcore/dependency/provider: can not declare type after first get dependency (for %s)", name)
+	}
+	if t == nil {
+		return goaterr.Errorf("goatcore/dependency/provider: declared type of %s can not be nil", name)
+	}
+	d.types[name] = t
+	return nil
 }
 
 // Set instance
 func (d *Provider) Set(name string, instance interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.blocked {
 		return goaterr.Errorf("goatcore/dependency/provider.Set: can not add new instance after got dependency (for %s)", name)
 	}
@@ -146,12 +287,15 @@ func (d *Provider) Set(name string, instance interface{}) error {
 		return goaterr.Errorf("goatcore/dependency/provider.Set: dependency %s factory exists (value musn't be overwrited)", name)
 	}
 	d.instances[name] = instance
+	d.graph.define(name, dependency.InstanceSource)
 	d.addKey(name)
 	return nil
 }
 
 // SetDefault set default dependency instance by name
 func (d *Provider) SetDefault(name string, instance interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.blocked {
 		return goaterr.Errorf("goatcore/dependency/provider.SetDefault: can not add default instance after got dependency (for %s)", name)
 	}
@@ -162,26 +306,22 @@ func (d *Provider) SetDefault(name string, instance interface{}) error {
 		return goaterr.Errorf("goatcore/dependency/provider.SetDefault: dependency %s factory exists (musn't be overwrited)", name)
 	}
 	d.defaultInstances[name] = instance
+	d.graph.define(name, dependency.DefaultInstanceSource)
 	d.addKey(name)
 	return nil
 }
 
 // AddFactory define a factory for dependency
 func (d *Provider) AddFactory(name string, factory dependency.Factory) error {
-	if d.blocked {
-		return goaterr.Errorf("goatcore/dependency/provider: can not add factory after first get dependency (for %s)", name)
-	}
-	if _, exist := d.factories[name]; exist {
-		return goaterr.Errorf("goatcore/dependency/provider: factory for '%s' double defined", name)
-	}
-	d.clean(name)
-	d.factories[name] = factory
-	d.addKey(name)
-	return nil
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.addFactory(name, factory, dependency.Singleton)
 }
 
 // AddDefaultFactory define a default factory for dependency
 func (d *Provider) AddDefaultFactory(name string, factory dependency.Factory) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.blocked {
 		return goaterr.Errorf("goatcore/dependency/provider: can not add factory after first get dependency (for %s)", name)
 	}
@@ -190,15 +330,172 @@ func (d *Provider) AddDefaultFactory(name string, factory dependency.Factory) er
 	}
 	if _, exist := d.factories[name]; exist {
 		// when we have got defined factory for a field default factory wont be used
+		d.graph.define(name, dependency.DefaultFactorySource)
 		return nil
 	}
 	d.defaultFactories[name] = factory
+	d.graph.define(name, dependency.DefaultFactorySource)
+	d.addKey(name)
+	return nil
+}
+
+// AddTransientFactory define a factory for dependency. The factory is called for every Get.
+func (d *Provider) AddTransientFactory(name string, factory dependency.Factory) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.addFactory(name, factory, dependency.Transient)
+}
+
+// AddScopedFactory define a factory for dependency. The factory is called once per scoped provider.
+func (d *Provider) AddScopedFactory(name string, factory dependency.Factory) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.addFactory(name, factory, dependency.Scoped)
+}
+
+func (d *Provider) addFactory(name string, factory dependency.Factory, lifetime dependency.Lifetime) error {
+	if d.blocked {
+		return goaterr.Errorf("goatcore/dependency/provider: can not add factory after first get dependency (for %s)", name)
+	}
+	if _, exist := d.factories[name]; exist {
+		return goaterr.Errorf("goatcore/dependency/provider: factory for '%s' double defined", name)
+	}
+	d.clean(name)
+	d.factories[name] = factory
+	if lifetime != dependency.Singleton {
+		d.lifetimes[name] = lifetime
+	}
+	d.graph.define(name, dependency.FactorySource)
 	d.addKey(name)
 	return nil
 }
 
+// AddDecorator add a decorator for dependency. Decorators are applied in registration order
+// to instances built by factories (instances defined by Set and SetDefault are not decorated).
+func (d *Provider) AddDecorator(name string, decorator dependency.Decorator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.blocked {
+		return goaterr.Errorf("goatcore/dependency/provider: can not add decorator after first get dependency (for %s)", name)
+	}
+	d.decorators[name] = append(d.decorators[name], decorator)
+	return nil
+}
+
+// CreateScope create a new scoped provider. It blocks the provider for new definitions.
+func (d *Provider) CreateScope() dependency.ScopedProvider {
+	d.Block()
+	return newScopedProvider(d)
+}
+
+// CreateChild create a child provider. It blocks the provider for new definitions.
+func (d *Provider) CreateChild() dependency.Provider {
+	d.Block()
+	return newChildProvider(d, d.tagname, d.injectors)
+}
+
+// Dispose release singletons created by factories. Instances implementing dependency.Disposable
+// or io.Closer are disposed in reverse creation order. Transient instances are not tracked.
+func (d *Provider) Dispose() error {
+	d.mu.Lock()
+	if d.disposed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.disposed = true
+	created := d.created
+	instances := make(map[string]interface{}, len(created))
+	for _, name := range created {
+		instances[name] = d.instances[name]
+	}
+	d.created = nil
+	d.mu.Unlock()
+	return disposeInstances(created, instances)
+}
+
 // InjectTo inject dependencies to object
 func (d *Provider) InjectTo(obj interface{}) error {
+	d.Block()
+	return inject(obj, d.tagname, d.Get, d.Resolve, d.injectors)
+}
+
+// build call the factory. The factory gets a provider view with its own callstack
+// (so cyclic dependencies are detected per call chain).
+func (d *Provider) build(stack callstack, name string, factory dependency.Factory) (instance interface{}, err error) {
+	c := newCall(d, stack, name, d.getFor, d.resolveFor)
+	if instance, err = factory(c); err != nil {
+		return nil, goaterr.Errorf("%v (dependency callstack: %v)", err, c.callstack.names)
+	}
+	if instance == nil {
+		return nil, goaterr.Errorf("factory for %s return nil as instance", name)
+	}
+	if instance, err = d.decorate(c, name, instance); err != nil {
+		return nil, err
+	}
+	return instance, d.checkType(name, instance)
+}
+
+// checkType return an error if the instance is not assignable to the declared type
+func (d *Provider) checkType(name string, instance interface{}) error {
+	d.mu.Lock()
+	t, ok := d.types[name]
+	d.mu.Unlock()
+	if ok && !reflect.TypeOf(instance).AssignableTo(t) {
+		return goaterr.Errorf("goatcore/dependency/provider: %s instance is %T (declared type is %s)", name, instance, t)
+	}
+	return nil
+}
+
+// decorate apply decorators to the instance in registration order
+func (d *Provider) decorate(c *call, name string, instance interface{}) (interface{}, error) {
+	var err error
+	d.mu.Lock()
+	decorators := d.decorators[name]
+	d.mu.Unlock()
+	for _, decorator := range decorators {
+		if instance, err = decorator(c, instance); err != nil {
+			return nil, goaterr.Errorf("decorator for %s: %v (dependency callstack: %v)", name, err, c.callstack.names)
+		}
+		if instance == nil {
+			return nil, goaterr.Errorf("decorator for %s return nil as instance", name)
+		}
+	}
+	return instance, nil
+}
+
+// owner return the provider defining the dependency. A child provider falls back to its parents
+// for dependencies it doesn't define.
+func (d *Provider) owner(name string) *Provider {
+	d.mu.Lock()
+	isLocal := d.hasKey(name)
+	d.mu.Unlock()
+	if !isLocal {
+		if parent, ok := d.parent.(*Provider); ok {
+			return parent.owner(name)
+		}
+	}
+	return d
+}
+
+func (d *Provider) lifetime(name string) dependency.Lifetime {
+	owner := d.owner(name)
+	owner.mu.Lock()
+	defer owner.mu.Unlock()
+	return owner.lifetimes[name]
+}
+
+func (d *Provider) factory(name string) dependency.Factory {
+	owner := d.owner(name)
+	owner.mu.Lock()
+	defer owner.mu.Unlock()
+	return owner.factories[name]
+}
+
+// inject set tagged struct fields by dependencies from get function and run injectors.
+// A field with an empty tag (like `dependency:""` or `dependency:"?"`) is injected by its type.
+// An exported untagged interface or pointer field is injected by its type if it is unset
+// and a dependency of the type exists.
+func inject(obj interface{}, tagname string, get func(string) (interface{}, error), resolveType func(reflect.Type) (interface{}, error), injectors []dependency.Injector) error {
 	structValue := reflect.ValueOf(obj).Elem()
 	for i := 0; i < structValue.NumField(); i++ {
 		var isRequired = true
@@ -206,9 +503,12 @@ func (d *Provider) InjectTo(obj interface{}) error {
 		valueField := structValue.Field(i)
 		structField := structValue.Type().Field(i)
 
-		depID := structField.Tag.Get(d.tagname)
-		if depID == "" {
-			continue
+		depID, ok := structField.Tag.Lookup(tagname)
+		if !ok {
+			if structField.Tag != "" || !isTypeInjectable(structField, valueField) {
+				continue
+			}
+			depID = "?"
 		}
 		if strings.HasPrefix(depID, "?") {
 			isRequired = false
@@ -220,7 +520,15 @@ func (d *Provider) InjectTo(obj interface{}) error {
 		if !valueField.CanSet() {
 			return goaterr.Errorf("goatcore/dependency/provider.InjectTo: Cannot set %s field value", structField.Name)
 		}
-		dep, err := d.Get(depID)
+		var (
+			dep interface{}
+			err error
+		)
+		if depID == "" {
+			dep, err = resolveType(valueField.Type())
+		} else {
+			dep, err = get(depID)
+		}
 		if err != nil {
 			if !isRequired {
 				continue
@@ -233,7 +541,7 @@ func (d *Provider) InjectTo(obj interface{}) error {
 		depValue := reflect.ValueOf(dep)
 		valueField.Set(depValue)
 	}
-	for _, injector := range d.injectors {
+	for _, injector := range injectors {
 		if err := injector.InjectTo(obj); err != nil {
 			return err
 		}
@@ -241,6 +549,51 @@ func (d *Provider) InjectTo(obj interface{}) error {
 	return nil
 }
 
+// isTypeInjectable return true for an exported and unset interface or pointer field
+func isTypeInjectable(structField reflect.StructField, valueField reflect.Value) bool {
+	if structField.PkgPath != "" || structField.Anonymous {
+		return false
+	}
+	kind := valueField.Kind()
+	if kind != reflect.Interface && kind != reflect.Ptr {
+		return false
+	}
+	return valueField.IsNil()
+}
+
+// callChain return dependencies for a call chain (a parent provider gets the child callstack
+// to detect cyclic dependencies and record relations in its graph)
+type callChain interface {
+	getFor(stack callstack, name string) (interface{}, error)
+}
+
+// typeIndex return dependencies types without creating them
+type typeIndex interface {
+	typeOf(name string) (reflect.Type, bool)
+}
+
+// resolve find the single dependency name assignable to the type. Dependencies of unknown
+// type (factories without declared type) are not matched.
+func resolve(t reflect.Type, keys []string, skip func(string) bool, typeOf func(string) (reflect.Type, bool)) (name string, err error) {
+	var matches []string
+	for _, key := range keys {
+		if skip(key) {
+			continue
+		}
+		if keyType, ok := typeOf(key); !ok || !keyType.AssignableTo(t) {
+			continue
+		}
+		matches = append(matches, key)
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", goaterr.Errorf("goatcore/dependency/provider: no dependency of %s type (factory dependencies must declare a type)", t)
+	}
+	return "", goaterr.Errorf("goatcore/dependency/provider: ambiguous dependency of %s type (%s)", t, strings.Join(matches, ", "))
+}
+
 func (d *Provider) clean(name string) {
 	if d.autoclean {
 		if _, exist := d.factories[name]; exist {
@@ -258,8 +611,9 @@ func (d *Provider) addKey(name string) {
 	}
 }
 
-func (d *Provider) isCalled(name string) bool {
-	for _, v := range d.callstack {
+// isCreated return true if the instance was created by a factory
+func (d *Provider) isCreated(name string) bool {
+	for _, v := range d.created {
 		if v == name {
 			return true
 		}
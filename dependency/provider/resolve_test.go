@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+)
+
+type ObjectWithTyped struct {
+	Dep MyDepInterface `inject:""`
+}
+
+type ObjectWithUnrequiredTyped struct {
+	Dep   MyDepInterface `inject:"?"`
+	Other TestInterface  `inject:"?"`
+}
+
+func TestInjectByType(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("one", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	obj := &ObjectWithTyped{}
+	if err := dp.InjectTo(obj); err != nil {
+		t.Error(err)
+		return
+	}
+	if obj.Dep == nil || !obj.Dep.IsItOk() {
+		t.Errorf("expected MyDep injected by type")
+	}
+}
+
+func TestInjectByTypeFromFactory(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("typed", func(dp dependency.Provider) (interface{}, error) {
+		obj := &ObjectWithTyped{}
+		if err := dp.InjectTo(obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	ins, err := dp.Get("typed")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ins.(*ObjectWithTyped).Dep == nil {
+		t.Errorf("expected MyDep injected by type")
+	}
+}
+
+func TestInjectByTypeAmbiguity(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("OtherDep", MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, "OtherDep"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.InjectTo(&ObjectWithTyped{}); err == nil {
+		t.Errorf("expected ambiguity error")
+	}
+}
+
+func TestInjectByTypeUnrequired(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	obj := &ObjectWithUnrequiredTyped{}
+	if err := dp.InjectTo(obj); err != nil {
+		t.Error(err)
+		return
+	}
+	if obj.Dep == nil {
+		t.Errorf("expected MyDep injected by type")
+	}
+	if obj.Other != nil {
+		t.Errorf("expected nil for unregistered type")
+	}
+}
+
+func TestGenericGetters(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	byName, err := dependency.GetAs[MyDepInterface](dp, MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	byType, err := dependency.Resolve[MyDepInterface](dp)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if byName != byType {
+		t.Errorf("expected the same instance")
+	}
+	if _, err = dependency.GetAs[TestInterface](dp, MyDepName); err == nil {
+		t.Errorf("expected type error")
+	}
+	if _, err = dependency.Resolve[TestInterface](dp); err == nil {
+		t.Errorf("expected error for unregistered type")
+	}
+}
+
+type testCloser struct{}
+
+func (c *testCloser) Close() error {
+	return nil
+}
+
+func TestResolveDoesNotCallFactories(t *testing.T) {
+	t.Parallel()
+	var (
+		calls int32
+		dp    = NewProvider(TagName)
+	)
+	if err := dp.AddTransientFactory("closer", func(dp dependency.Provider) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return &testCloser{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddDefaultFactory("undeclared", func(dp dependency.Provider) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return &MyDep{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[io.Closer](dp, "closer"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.Set(MyDepName, &MyDep{}); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		obj := &ObjectWithTyped{}
+		if err := dp.InjectTo(obj); err != nil {
+			t.Error(err)
+			return
+		}
+		if obj.Dep == nil {
+			t.Errorf("expected MyDep instance injected by type")
+			return
+		}
+	}
+	if calls != 0 {
+		t.Errorf("factories shouldn't be called by type lookup and they were called %d times", calls)
+	}
+	if _, err := dependency.Resolve[io.Closer](dp); err != nil {
+		t.Error(err)
+		return
+	}
+	if calls != 1 {
+		t.Errorf("expected only the matched factory call and take %d calls", calls)
+	}
+}
+
+func TestDeclaredTypeMismatch(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("closer", MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[io.Closer](dp, "closer"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get("closer"); err == nil {
+		t.Errorf("expected error for instance which doesn't implement the declared type")
+	}
+}
+
+func TestResolveIgnoresCreatedInstances(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("undeclared", func(dp dependency.Provider) (interface{}, error) {
+		return &MyDep{}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dependency.Resolve[MyDepInterface](dp); err == nil {
+		t.Errorf("expected error for undeclared type")
+		return
+	}
+	if _, err := dp.Get("undeclared"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dependency.Resolve[MyDepInterface](dp); err == nil {
+		t.Errorf("expected the same result after the instance was created")
+	}
+}
+
+type ObjectWithUntagged struct {
+	Dep     MyDepInterface
+	Other   TestInterface
+	Preset  MyDepInterface
+	private MyDepInterface
+	Named   MyDepInterface `json:"named"`
+}
+
+func TestInjectUntaggedByType(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dependency.DeclareType[MyDepInterface](dp, MyDepName); err != nil {
+		t.Error(err)
+		return
+	}
+	preset := &MyDep{}
+	obj := &ObjectWithUntagged{
+		Preset: preset,
+	}
+	if err := dp.InjectTo(obj); err != nil {
+		t.Error(err)
+		return
+	}
+	if obj.Dep == nil || !obj.Dep.IsItOk() {
+		t.Errorf("expected MyDep injected by type")
+	}
+	if obj.Other != nil {
+		t.Errorf("expected nil for unregistered type")
+	}
+	if obj.Preset != preset {
+		t.Errorf("a set field musn't be overwritten")
+	}
+	if obj.private != nil || obj.Named != nil {
+		t.Errorf("unexported and tagged by other tags fields musn't be injected")
+	}
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// ScopedProvider distribute dependencies for a single scope.
+// Scoped instances are cached in the provider, transient are created for every Get
+// and singletons are taken from the parent provider. It is safe for concurrent use.
+type ScopedProvider struct {
+	mu        sync.Mutex
+	parent    *Provider
+	instances map[string]interface{}
+	pending   map[string]*pendingInstance
+	created   []string
+	disposed  bool
+}
+
+func newScopedProvider(parent *Provider) *ScopedProvider {
+	return &ScopedProvider{
+		parent:    parent,
+		instances: map[string]interface{}{},
+		pending:   map[string]*pendingInstance{},
+		created:   []string{},
+	}
+}
+
+// Get return instance by name
+func (sp *ScopedProvider) Get(name string) (instance interface{}, err error) {
+	return sp.getFor(callstack{}, name)
+}
+
+func (sp *ScopedProvider) getFor(stack callstack, name string) (instance interface{}, err error) {
+	if sp.isDisposed() {
+		return nil, goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: can not get %s from disposed scope", name)
+	}
+	sp.parent.mu.Lock()
+	sp.parent.graph.record(stack.caller(), name)
+	sp.parent.mu.Unlock()
+	return sp.get(stack, name)
+}
+
+func (sp *ScopedProvider) get(stack callstack, name string) (instance interface{}, err error) {
+	switch sp.parent.lifetime(name) {
+	case dependency.Transient:
+		return sp.build(stack, name)
+	case dependency.Scoped:
+		return sp.getScoped(stack, name)
+	}
+	return sp.parent.get(stack, name)
+}
+
+func (sp *ScopedProvider) getScoped(stack callstack, name string) (instance interface{}, err error) {
+	if stack.has(name) {
+		return nil, stack.cycleError(name)
+	}
+	sp.mu.Lock()
+	if sp.disposed {
+		sp.mu.Unlock()
+		return nil, goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: can not get %s from disposed scope", name)
+	}
+	if instance, exist := sp.instances[name]; exist {
+		sp.mu.Unlock()
+		return instance, nil
+	}
+	if pending, exist := sp.pending[name]; exist {
+		sp.mu.Unlock()
+		return stack.wait(pending)
+	}
+	pending := &pendingInstance{
+		name: name,
+		done: make(chan struct{}),
+	}
+	sp.pending[name] = pending
+	sp.mu.Unlock()
+	stack.build(pending, func(owner callstack) {
+		pending.instance, pending.err = sp.build(owner, name)
+	})
+	sp.mu.Lock()
+	delete(sp.pending, name)
+	disposed := sp.disposed
+	if pending.err == nil && !disposed {
+		sp.instances[name] = pending.instance
+		sp.created = append(sp.created, name)
+	}
+	sp.mu.Unlock()
+	if pending.err == nil && disposed {
+		// the scope was disposed during the build
+		disposeInstance(pending.instance)
+		pending.instance, pending.err = nil, goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: scope disposed while %s was created", name)
+	}
+	close(pending.done)
+	return pending.instance, pending.err
+}
+
+// Resolve return the single dependency assignable to the type
+func (sp *ScopedProvider) Resolve(t reflect.Type) (interface{}, error) {
+	return sp.resolveFor(callstack{}, t)
+}
+
+func (sp *ScopedProvider) resolveFor(stack callstack, t reflect.Type) (interface{}, error) {
+	if sp.isDisposed() {
+		return nil, goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: can not resolve %s from disposed scope", t)
+	}
+	keys, _ := sp.parent.Keys()
+	name, err := resolve(t, keys, func(name string) bool {
+		return stack.has(name)
+	}, sp.typeOf)
+	if err != nil {
+		return nil, err
+	}
+	sp.parent.mu.Lock()
+	sp.parent.graph.record(stack.caller(), name)
+	sp.parent.mu.Unlock()
+	return sp.get(stack, name)
+}
+
+// typeOf return the dependency type without creating it (see Provider.typeOf)
+func (sp *ScopedProvider) typeOf(name string) (reflect.Type, bool) {
+	if sp.parent.lifetime(name) == dependency.Scoped {
+		sp.mu.Lock()
+		instance, exist := sp.instances[name]
+		sp.mu.Unlock()
+		if exist {
+			return reflect.TypeOf(instance), true
+		}
+	}
+	return sp.parent.typeOf(name)
+}
+
+func (sp *ScopedProvider) build(stack callstack, name string) (instance interface{}, err error) {
+	if stack.has(name) {
+		return nil, stack.cycleError(name)
+	}
+	// the dependency can be defined by a parent of a child provider
+	owner := sp.parent.owner(name)
+	c := newCall(sp.parent, stack, name, sp.getFor, sp.resolveFor)
+	c.Provider = sp
+	if instance, err = owner.factory(name)(c); err != nil {
+		return nil, goaterr.Errorf("%v (dependency callstack: %v)", err, c.callstack.names)
+	}
+	if instance == nil {
+		return nil, goaterr.Errorf("factory for %s return nil as instance", name)
+	}
+	if instance, err = owner.decorate(c, name, instance); err != nil {
+		return nil, err
+	}
+	return instance, owner.checkType(name, instance)
+}
+
+func (sp *ScopedProvider) isDisposed() bool {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	return sp.disposed
+}
+
+// InjectTo inject dependencies to object
+func (sp *ScopedProvider) InjectTo(obj interface{}) error {
+	return inject(obj, sp.parent.tagname, sp.Get, sp.Resolve, sp.parent.injectors)
+}
+
+// Graph return dependencies graph of the parent provider
+func (sp *ScopedProvider) Graph() dependency.Graph {
+	return sp.parent.Graph()
+}
+
+// Keys return list of all defined dependencies names
+func (sp *ScopedProvider) Keys() ([]string, error) {
+	return sp.parent.Keys()
+}
+
+// CreateScope create a new sibling scoped provider
+func (sp *ScopedProvider) CreateScope() dependency.ScopedProvider {
+	return sp.parent.CreateScope()
+}
+
+// CreateChild create a child provider. The child falls back to the scoped provider.
+func (sp *ScopedProvider) CreateChild() dependency.Provider {
+	return newChildProvider(sp, sp.parent.tagname, sp.parent.injectors)
+}
+
+// Dispose release scoped instances. Instances implementing dependency.Disposable
+// or io.Closer are disposed in reverse creation order.
+func (sp *ScopedProvider) Dispose() error {
+	sp.mu.Lock()
+	if sp.disposed {
+		sp.mu.Unlock()
+		return nil
+	}
+	sp.disposed = true
+	instances, created := sp.instances, sp.created
+	sp.instances, sp.created = nil, nil
+	sp.mu.Unlock()
+	return disposeInstances(created, instances)
+}
+
+// AddInjectors is unsupported for scoped provider
+func (sp *ScopedProvider) AddInjectors([]dependency.Injector) error {
+	return goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: can not add injectors to scoped provider")
+}
+
+// Set is unsupported for scoped provider
+func (sp *ScopedProvider) Set(name string, instance interface{}) error {
+	return sp.blockedError(name)
+}
+
+// SetDefault is unsupported for scoped provider
+func (sp *ScopedProvider) SetDefault(name string, instance interface{}) error {
+	return sp.blockedError(name)
+}
+
+// AddFactory is unsupported for scoped provider
+func (sp *ScopedProvider) AddFactory(name string, factory dependency.Factory) error {
+	return sp.blockedError(name)
+}
+
+// AddDefaultFactory is unsupported for scoped provider
+func (sp *ScopedProvider) AddDefaultFactory(name string, factory dependency.Factory) error {
+	return sp.blockedError(name)
+}
+
+// AddTransientFactory is unsupported for scoped provider
+func (sp *ScopedProvider) AddTransientFactory(name string, factory dependency.Factory) error {
+	return sp.blockedError(name)
+}
+
+// AddScopedFactory is unsupported for scoped provider
+func (sp *ScopedProvider) AddScopedFactory(name string, factory dependency.Factory) error {
+	return sp.blockedError(name)
+}
+
+// DeclareType is unsupported for scoped provider
+func (sp *ScopedProvider) DeclareType(name string, t reflect.Type) error {
+	return sp.blockedError(name)
+}
+
+// AddDecorator is unsupported for scoped provider
+func (sp *ScopedProvider) AddDecorator(name string, decorator dependency.Decorator) error {
+	return sp.blockedError(name)
+}
+
+func (sp *ScopedProvider) blockedError(name string) error {
+	return goaterr.Errorf("goatcore/dependency/provider.ScopedProvider: can not define %s dependency in scoped provider", name)
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/dependency"
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+type closeRecorder struct {
+	name   string
+	closed *[]string
+}
+
+func (c *closeRecorder) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestTransientFactory(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddTransientFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	first, err := dp.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second, err := dp.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if first == second {
+		t.Errorf("transient dependency should be created for every Get")
+	}
+}
+
+func TestScopedFactory(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.Get(MyDepName); err == nil {
+		t.Errorf("scoped dependency should be unavailable outside a scope")
+	}
+	scope1 := dp.CreateScope()
+	scope2 := dp.CreateScope()
+	first, err := scope1.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second, err := scope1.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if first != second {
+		t.Errorf("scoped dependency should be created once per scope")
+	}
+	other, err := scope2.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if first == other {
+		t.Errorf("scoped dependency should be separated between scopes")
+	}
+}
+
+func TestScopedProviderSharesSingletons(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddDefaultFactory("one", OneFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddScopedFactory("two", TwoFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateScope()
+	twoIns, err := scope.Get("two")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !twoIns.(TestInterface).Test() {
+		t.Errorf("singleton should be injected to scoped dependency")
+	}
+	if err = scope.AddFactory("three", OneFactory); err == nil {
+		t.Errorf("scoped provider should be blocked for new definitions")
+	}
+}
+
+func TestScopedProviderDispose(t *testing.T) {
+	t.Parallel()
+	var closed []string
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory("first", func(dp dependency.Provider) (interface{}, error) {
+		return &closeRecorder{name: "first", closed: &closed}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddScopedFactory("second", func(dp dependency.Provider) (interface{}, error) {
+		if _, err := dp.Get("first"); err != nil {
+			return nil, err
+		}
+		return &closeRecorder{name: "second", closed: &closed}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateScope()
+	if _, err := scope.Get("second"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := scope.Dispose(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(closed) != 2 || closed[0] != "second" || closed[1] != "first" {
+		t.Errorf("expected instances closed in reverse creation order and take %v", closed)
+	}
+	if err := scope.Dispose(); err != nil {
+		t.Errorf("dispose should be idempotent: %v", err)
+	}
+	if _, err := scope.Get("first"); err == nil {
+		t.Errorf("disposed scope should return error")
+	}
+}
+
+func TestScopedProviderPreventCircle(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory(MyCircleDepName, MyCircleDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := dp.CreateScope().Get(MyCircleDepName); err == nil {
+		t.Errorf("should return error when dependencies are circled")
+	}
+}
+
+func TestScopedProviderFactoryError(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory(MyDepName, func(dp dependency.Provider) (interface{}, error) {
+		return nil, goaterr.Errorf("some error")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateScope()
+	if _, err := scope.Get(MyDepName); err == nil {
+		t.Errorf("expected factory error")
+	}
+	if _, err := scope.Get(MyDepName); err == nil {
+		t.Errorf("expected factory error (without cyclic dependency false positive)")
+	}
+}
+
+func TestScopeOfChildProvider(t *testing.T) {
+	t.Parallel()
+	dp := NewProvider(TagName)
+	if err := dp.AddScopedFactory(MyDepName, MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := dp.AddTransientFactory("transient", MyDepFactory); err != nil {
+		t.Error(err)
+		return
+	}
+	scope := dp.CreateChild().CreateScope()
+	first, err := scope.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second, err := scope.Get(MyDepName)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if first != second {
+		t.Errorf("scoped dependency should be created once per scope")
+	}
+	if _, err = scope.Get("transient"); err != nil {
+		t.Error(err)
+		return
+	}
+}
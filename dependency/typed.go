@@ -0,0 +1,36 @@
+package dependency
+
+import (
+	"reflect"
+
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+// GetAs return a dependency by name as T type
+func GetAs[T any](dp Provider, name string) (result T, err error) {
+	var (
+		ins interface{}
+		ok  bool
+	)
+	if ins, err = dp.Get(name); err != nil {
+		return result, err
+	}
+	if result, ok = ins.(T); !ok {
+		return result, goaterr.Errorf("goatcore/dependency: %s dependency is %T (expected %s)", name, ins, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return result, nil
+}
+
+// DeclareType declare T type (usually an interface) for the dependency (see Provider.DeclareType)
+func DeclareType[T any](dp Provider, name string) error {
+	return dp.DeclareType(name, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Resolve return the single dependency assignable to T type (usually an interface)
+func Resolve[T any](dp Provider) (result T, err error) {
+	var ins interface{}
+	if ins, err = dp.Resolve(reflect.TypeOf((*T)(nil)).Elem()); err != nil {
+		return result, err
+	}
+	return ins.(T), nil
+}
@@ -0,0 +1,33 @@
+package toml
+
+import (
+	"bytes"
+	"path/filepath"
+
+	tomlenc "github.com/BurntSushi/toml"
+	"github.com/goatcms/goatcore/filesystem"
+)
+
+// ReadTOML read data from toml file to object
+func ReadTOML(fs filesystem.Filespace, src string, object interface{}) error {
+	data, err := fs.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return tomlenc.Unmarshal(data, object)
+}
+
+// WriteTOML write data from object to toml file
+func WriteTOML(fs filesystem.Filespace, path string, object interface{}) error {
+	buf := &bytes.Buffer{}
+	if err := tomlenc.NewEncoder(buf).Encode(object); err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if !fs.IsDir(dir) {
+		if err := fs.MkdirAll(dir, 0777); err != nil {
+			return err
+		}
+	}
+	return fs.WriteFile(path, buf.Bytes(), 0777)
+}
@@ -0,0 +1,46 @@
+package toml_test
+
+import (
+	"testing"
+
+	"github.com/goatcms/goatcore/filesystem/filespace/memfs"
+	"github.com/goatcms/goatcore/filesystem/toml"
+)
+
+type TestObject struct {
+	Value1 string `toml:"value1"`
+	Value2 string `toml:"value2"`
+}
+
+func TestWriteAndRead(t *testing.T) {
+	t.Parallel()
+	var writeObject, readObject TestObject
+	// init
+	fs, err := memfs.NewFilespace()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// create test data
+	path := "fyfile.toml"
+	writeObject = TestObject{
+		Value1: "str1",
+		Value2: "str2",
+	}
+	// write & read
+	if err = toml.WriteTOML(fs, path, writeObject); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = toml.ReadTOML(fs, path, &readObject); err != nil {
+		t.Error(err)
+		return
+	}
+	// test node type
+	if !fs.IsFile(path) {
+		t.Error("filesystem does not contain the file after write")
+	}
+	if writeObject.Value1 != readObject.Value1 || writeObject.Value2 != readObject.Value2 {
+		t.Error("read data is wrong", writeObject, readObject)
+	}
+}
@@ -0,0 +1,32 @@
+package yaml
+
+import (
+	"path/filepath"
+
+	"github.com/goatcms/goatcore/filesystem"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ReadYAML read data from yaml file to object
+func ReadYAML(fs filesystem.Filespace, src string, object interface{}) error {
+	data, err := fs.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return yamlv3.Unmarshal(data, object)
+}
+
+// WriteYAML write data from object to yaml file
+func WriteYAML(fs filesystem.Filespace, path string, object interface{}) error {
+	data, err := yamlv3.Marshal(object)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if !fs.IsDir(dir) {
+		if err := fs.MkdirAll(dir, 0777); err != nil {
+			return err
+		}
+	}
+	return fs.WriteFile(path, data, 0777)
+}
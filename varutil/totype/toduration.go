@@ -0,0 +1,8 @@
+package totype
+
+import "time"
+
+// StringToDuration convert string (like "1h30m" or "500ms") to time.Duration
+func StringToDuration(from string) (time.Duration, error) {
+	return time.ParseDuration(from)
+}
@@ -0,0 +1,193 @@
+package totype
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goatcms/goatcore/varutil/goaterr"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ToValue convert a value to the t type. Strings are parsed to numbers, bools, time.Duration,
+// slices (comma separated) and structs/maps (JSON). Maps and slices from decoded data
+// (like JSON or YAML config) are converted to structs and typed slices.
+func ToValue(from interface{}, t reflect.Type) (result reflect.Value, err error) {
+	if from == nil {
+		return result, goaterr.Errorf("totype.ToValue: can not convert nil to %s", t)
+	}
+	fromValue := reflect.ValueOf(from)
+	if fromValue.Type().AssignableTo(t) {
+		return fromValue, nil
+	}
+	if s, ok := from.(string); ok {
+		return StringToValue(s, t)
+	}
+	switch {
+	case isNumber(t.Kind()) && isNumber(fromValue.Kind()):
+		return numberToValue(fromValue, t)
+	case t.Kind() == reflect.Slice && fromValue.Kind() == reflect.Slice:
+		result = reflect.MakeSlice(t, fromValue.Len(), fromValue.Len())
+		for i := 0; i < fromValue.Len(); i++ {
+			var elem reflect.Value
+			if elem, err = ToValue(fromValue.Index(i).Interface(), t.Elem()); err != nil {
+				return result, err
+			}
+			result.Index(i).Set(elem)
+		}
+		return result, nil
+	case fromValue.Kind() == reflect.Map && isJSONObject(t):
+		var data []byte
+		if data, err = json.Marshal(from); err != nil {
+			return result, err
+		}
+		return jsonToValue(data, t)
+	}
+	return result, goaterr.Errorf("totype.ToValue: can not convert %T to %s", from, t)
+}
+
+// StringToValue convert string to the t type
+func StringToValue(from string, t reflect.Type) (result reflect.Value, err error) {
+	var v interface{}
+	if t == durationType {
+		if v, err = StringToDuration(from); err != nil {
+			return result, err
+		}
+		return reflect.ValueOf(v).Convert(t), nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(from).Convert(t), nil
+	case reflect.Bool:
+		if v, err = StringToBool(from); err != nil {
+			return result, err
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err = strconv.ParseInt(from, DefeultNumericBase, t.Bits()); err != nil {
+			return result, err
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err = strconv.ParseUint(from, DefeultNumericBase, t.Bits()); err != nil {
+			return result, err
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err = strconv.ParseFloat(from, t.Bits()); err != nil {
+			return result, err
+		}
+	case reflect.Slice:
+		if from == "" {
+			return reflect.MakeSlice(t, 0, 0), nil
+		}
+		parts := strings.Split(from, ",")
+		result = reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			var elem reflect.Value
+			if elem, err = StringToValue(strings.TrimSpace(part), t.Elem()); err != nil {
+				return result, err
+			}
+			result.Index(i).Set(elem)
+		}
+		return result, nil
+	default:
+		if !isJSONObject(t) {
+			return result, goaterr.Errorf("totype.StringToValue: unsupported %s type", t)
+		}
+		return jsonToValue([]byte(from), t)
+	}
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// numberToValue convert a number to the t number type. It returns an error if the value
+// has a fractional part (for integer types) or overflows the t type.
+func numberToValue(from reflect.Value, t reflect.Type) (result reflect.Value, err error) {
+	result = reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		switch from.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = from.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if from.Uint() > math.MaxInt64 {
+				return result, goaterr.Errorf("totype.ToValue: %v overflows %s", from, t)
+			}
+			v = int64(from.Uint())
+		default:
+			f := from.Float()
+			if f != math.Trunc(f) {
+				return result, goaterr.Errorf("totype.ToValue: %v has a fractional part (can not convert it to %s)", f, t)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return result, goaterr.Errorf("totype.ToValue: %v overflows %s", f, t)
+			}
+			v = int64(f)
+		}
+		if result.OverflowInt(v) {
+			return result, goaterr.Errorf("totype.ToValue: %v overflows %s", v, t)
+		}
+		result.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		switch from.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if from.Int() < 0 {
+				return result, goaterr.Errorf("totype.ToValue: %v overflows %s", from, t)
+			}
+			v = uint64(from.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v = from.Uint()
+		default:
+			f := from.Float()
+			if f != math.Trunc(f) {
+				return result, goaterr.Errorf("totype.ToValue: %v has a fractional part (can not convert it to %s)", f, t)
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return result, goaterr.Errorf("totype.ToValue: %v overflows %s", f, t)
+			}
+			v = uint64(f)
+		}
+		if result.OverflowUint(v) {
+			return result, goaterr.Errorf("totype.ToValue: %v overflows %s", v, t)
+		}
+		result.SetUint(v)
+	default:
+		v := from.Convert(reflect.TypeOf(float64(0))).Float()
+		if result.OverflowFloat(v) {
+			return result, goaterr.Errorf("totype.ToValue: %v overflows %s", v, t)
+		}
+		result.SetFloat(v)
+	}
+	return result, nil
+}
+
+func jsonToValue(data []byte, t reflect.Type) (result reflect.Value, err error) {
+	ptr := reflect.New(t)
+	if err = json.Unmarshal(data, ptr.Interface()); err != nil {
+		return result, goaterr.Wrapf("totype: can not decode %s", err, t)
+	}
+	return ptr.Elem(), nil
+}
+
+func isJSONObject(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Ptr:
+		return t.Elem().Kind() == reflect.Struct
+	}
+	return false
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
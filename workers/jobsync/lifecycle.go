@@ -3,6 +3,7 @@ package jobsync
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/goatcms/goatcore/varutil/goaterr"
@@ -11,8 +12,10 @@ import (
 // Lifecycle is execution lifecycle controller object
 type Lifecycle struct {
 	mutex      sync.Mutex
+	parent     context.Context
 	ctx        context.Context
 	cancel     context.CancelFunc
+	killed     int32
 	strictMode bool
 	errors     []error
 	muStep     sync.RWMutex
@@ -21,12 +24,22 @@ type Lifecycle struct {
 
 // NewLifecycle create new Lifecycle instance
 func NewLifecycle(lifetime time.Duration, strictMode bool) (lifecycle *Lifecycle) {
-	deadline := time.Now().Add(lifetime)
+	return NewLifecycleWithDeadline(time.Now().Add(lifetime), strictMode)
+}
+
+// NewLifecycleWithDeadline create new Lifecycle instance killed on the deadline
+func NewLifecycleWithDeadline(deadline time.Time, strictMode bool) (lifecycle *Lifecycle) {
+	return NewChildLifecycle(context.Background(), deadline, strictMode)
+}
+
+// NewChildLifecycle create new Lifecycle instance killed on the deadline or when the parent context is done
+func NewChildLifecycle(parent context.Context, deadline time.Time, strictMode bool) (lifecycle *Lifecycle) {
 	lifecycle = &Lifecycle{
+		parent:     parent,
 		strictMode: strictMode,
 		errors:     []error{},
 	}
-	lifecycle.ctx, lifecycle.cancel = context.WithDeadline(context.Background(), deadline)
+	lifecycle.ctx, lifecycle.cancel = context.WithDeadline(parent, deadline)
 	return lifecycle
 }
 
@@ -37,6 +50,7 @@ func (lifecycle *Lifecycle) Context() context.Context {
 
 // Kill set lifecycle kill flag to true. It is signal to stop related goroutines
 func (lifecycle *Lifecycle) Kill() {
+	atomic.StoreInt32(&lifecycle.killed, 1)
 	lifecycle.cancel()
 }
 
@@ -60,8 +74,12 @@ func (lifecycle *Lifecycle) Error(e ...error) {
 	lifecycle.mutex.Unlock()
 }
 
-// Errors return lifecycle error array
+// Errors return lifecycle error array. The context error of a lifecycle ended by the parent
+// context is skipped (the parent reports it).
 func (lifecycle *Lifecycle) Errors() []error {
+	if lifecycle.parent.Err() != nil && atomic.LoadInt32(&lifecycle.killed) == 0 {
+		return goaterr.AppendError(nil, lifecycle.errors...)
+	}
 	return goaterr.AppendError(lifecycle.errors, lifecycle.ctx.Err())
 }
 
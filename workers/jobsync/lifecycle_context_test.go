@@ -20,3 +20,21 @@ func TestCancelContextWhenKill(t *testing.T) {
 		}
 	}
 }
+
+func TestChildLifecycleSkipParentContextError(t *testing.T) {
+	t.Parallel()
+	parent := NewLifecycle(time.Minute, true)
+	child := NewChildLifecycle(parent.Context(), time.Now().Add(time.Minute), true)
+	parent.Kill()
+	<-child.Context().Done()
+	if errs := child.Errors(); len(errs) != 0 {
+		t.Errorf("the parent context error should be reported by the parent only and take %v", errs)
+	}
+	if errs := parent.Errors(); len(errs) != 1 {
+		t.Errorf("expected the parent context error and take %v", errs)
+	}
+	child.Kill()
+	if errs := child.Errors(); len(errs) != 1 {
+		t.Errorf("expected the context error of a killed child and take %v", errs)
+	}
+}